@@ -5,6 +5,7 @@ go 1.23.0
 require (
 	github.com/getsentry/sentry-go v0.33.0
 	github.com/prometheus/client_golang v1.22.0
+	github.com/prometheus/client_model v0.6.2
 	go.uber.org/multierr v1.11.0
 	go.uber.org/zap v1.27.0
 	moul.io/http2curl v1.0.0
@@ -14,7 +15,6 @@ require (
 	github.com/beorn7/perks v1.0.1 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
-	github.com/prometheus/client_model v0.6.2 // indirect
 	github.com/prometheus/common v0.64.0 // indirect
 	github.com/prometheus/procfs v0.16.1 // indirect
 	github.com/smartystreets/goconvey v1.8.1 // indirect
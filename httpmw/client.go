@@ -0,0 +1,144 @@
+package httpmw
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+	"github.com/Pacman29/observability/metrics"
+)
+
+const (
+	ClientRequestsMetric        = "http_client_requests_total"
+	ClientRequestDurationMetric = "http_client_request_duration_ms"
+
+	ClientFailedMessage = "http client request failed"
+	ClientSlowMessage   = "http client request is slow"
+
+	HostLabel = "host"
+	// errorStatusClass is the status class of the calls which got no response.
+	errorStatusClass = "error"
+)
+
+// WithoutInstrumentation disables the logs, metrics and headers of NewRoundTripper for the requests with ctx.
+func WithoutInstrumentation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipKey, true)
+}
+
+func skipped(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipKey).(bool)
+	return skip
+}
+
+type roundTripper struct {
+	next http.RoundTripper
+	l    logger.Logger
+	m    metrics.Metrics
+	o    *clientOptions
+}
+
+// NewRoundTripper instruments the outgoing requests: failed and slow calls are logged with the curl form of the request,
+// the latency and status class are recorded labelled by host and method, the request ID and the selected tags
+// of the context are passed to the called service in the headers. http.DefaultTransport is used if next is nil.
+func NewRoundTripper(next http.RoundTripper, l logger.Logger, m metrics.Metrics, opts ...ClientOption) http.RoundTripper {
+	o := newClientOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &roundTripper{
+		next: next,
+		l:    l,
+		m:    m,
+		o:    o,
+	}
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if skipped(ctx) {
+		return rt.next.RoundTrip(req)
+	}
+
+	req = rt.withHeaders(ctx, req)
+
+	start := time.Now()
+	resp, err := rt.next.RoundTrip(req)
+	duration := time.Since(start)
+
+	status := errorStatusClass
+	if err == nil {
+		status = statusClass(resp.StatusCode)
+	}
+	tags := metrics.WithTags(map[string]string{
+		HostLabel:        req.URL.Host,
+		MethodLabel:      req.Method,
+		StatusClassLabel: status,
+	})
+	// the tags of the context, e.g. the request ID, would make the labels unbounded
+	rt.m.Increment(context.Background(), ClientRequestsMetric, 1, tags)
+	rt.m.Duration(context.Background(), ClientRequestDurationMetric, duration, tags, metrics.WithBuckets(rt.o.durationBuckets...))
+
+	switch {
+	case err != nil:
+		rt.l.Error(rt.l.WithRequest(ctx, logRequest(req)), ClientFailedMessage, err, rt.l.Field("duration", duration))
+	case rt.o.slowThreshold > 0 && duration >= rt.o.slowThreshold:
+		rt.l.Warning(rt.l.WithRequest(ctx, logRequest(req)), ClientSlowMessage,
+			rt.l.Field("duration", duration),
+			rt.l.Field("status", resp.StatusCode),
+		)
+	}
+	return resp, err
+}
+
+// withHeaders returns the copy of req with the propagated headers, the RoundTripper must not modify the request.
+// Headers which are already set by the caller are kept.
+func (rt *roundTripper) withHeaders(ctx context.Context, req *http.Request) *http.Request {
+	headers := make(map[string]string, len(rt.o.tagHeaders)+1)
+
+	tags := logger.ContextTags(ctx)
+	for tag, header := range rt.o.tagHeaders {
+		if v, ok := tags[tag]; ok {
+			headers[header] = v
+		}
+	}
+
+	id := RequestID(ctx)
+	if id == "" {
+		id = tags[RequestIDTag]
+	}
+	if id != "" {
+		headers[rt.o.requestIDHeader] = id
+	}
+
+	var res *http.Request
+	for header, v := range headers {
+		if req.Header.Get(header) != "" {
+			continue
+		}
+		if res == nil {
+			res = req.Clone(ctx)
+		}
+		res.Header.Set(header, v)
+	}
+	if res == nil {
+		return req
+	}
+	return res
+}
+
+// logRequest returns the copy of req for the curl form in the log, the body of req is already consumed by the call.
+func logRequest(req *http.Request) *http.Request {
+	res := req.Clone(req.Context())
+	res.Body = http.NoBody
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			res.Body = body
+		}
+	}
+	return res
+}
@@ -0,0 +1,51 @@
+package httpmw
+
+import "time"
+
+type clientOptions struct {
+	requestIDHeader string
+	slowThreshold   time.Duration
+	tagHeaders      map[string]string
+	durationBuckets []float64
+}
+
+type ClientOption func(o *clientOptions)
+
+func newClientOptions() *clientOptions {
+	return &clientOptions{
+		requestIDHeader: DefaultRequestIDHeader,
+		slowThreshold:   time.Second,
+		tagHeaders:      map[string]string{},
+		durationBuckets: DefaultDurationBuckets,
+	}
+}
+
+// WithClientRequestIDHeader sets the header which passes the request ID to the called service, X-Request-ID by default.
+func WithClientRequestIDHeader(name string) ClientOption {
+	return func(o *clientOptions) {
+		o.requestIDHeader = name
+	}
+}
+
+// WithSlowThreshold sets the duration after which the call is logged as slow, 1 second by default.
+// Zero disables the slow calls log.
+func WithSlowThreshold(d time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.slowThreshold = d
+	}
+}
+
+// WithPropagatedTag passes the logger tag of the context to the called service in the header.
+func WithPropagatedTag(tag string, header string) ClientOption {
+	return func(o *clientOptions) {
+		o.tagHeaders[tag] = header
+	}
+}
+
+// WithClientDurationBuckets sets the histogram buckets of the call duration in milliseconds,
+// DefaultDurationBuckets by default.
+func WithClientDurationBuckets(buckets ...float64) ClientOption {
+	return func(o *clientOptions) {
+		o.durationBuckets = buckets
+	}
+}
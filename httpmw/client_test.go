@@ -0,0 +1,86 @@
+package httpmw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+	"github.com/Pacman29/observability/metrics"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRoundTripper(t *testing.T) {
+	ld, md := &recordLogDriver{}, &recordMetricsDriver{}
+	l, m := logger.New(ld), metrics.New(md)
+
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		time.Sleep(5 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRoundTripper(nil, l, m,
+		WithSlowThreshold(time.Millisecond),
+		WithPropagatedTag("tenant", "X-Tenant"),
+	)}
+
+	ctx := l.WithTags(context.Background(), map[string]string{RequestIDTag: "abc", "tenant": "acme", "user": "bob"})
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	if headers.Get(DefaultRequestIDHeader) != "abc" || headers.Get("X-Tenant") != "acme" || headers.Get("User") != "" {
+		t.Errorf("Unexpected propagated headers: %v", headers)
+	}
+	if req.Header.Get(DefaultRequestIDHeader) != "" {
+		t.Error("Expected the original request not to be modified")
+	}
+	if len(ld.entries) != 1 || ld.entries[0].msg != ClientSlowMessage || ld.entries[0].level != logger.WarningLevel {
+		t.Errorf("Expected slow call log, got %+v", ld.entries)
+	}
+	if len(md.metrics) != 2 || md.metrics[0].key != ClientRequestsMetric || md.metrics[0].tags[StatusClassLabel] != "2xx" ||
+		md.metrics[0].tags[HostLabel] != req.URL.Host || md.metrics[0].tags[MethodLabel] != http.MethodGet ||
+		!slices.Equal(md.metrics[1].buckets, DefaultDurationBuckets) {
+		t.Errorf("Unexpected metrics: %+v", md.metrics)
+	}
+}
+
+func TestRoundTripperFailure(t *testing.T) {
+	ld, md := &recordLogDriver{}, &recordMetricsDriver{}
+	l, m := logger.New(ld), metrics.New(md)
+
+	rt := NewRoundTripper(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}), l, m)
+
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/users", nil)
+	if _, err := rt.RoundTrip(req); err == nil {
+		t.Fatal("Expected error")
+	}
+	if len(ld.entries) != 1 || ld.entries[0].msg != ClientFailedMessage || ld.entries[0].level != logger.ErrorLevel {
+		t.Errorf("Expected failure log, got %+v", ld.entries)
+	}
+	if md.metrics[0].tags[StatusClassLabel] != errorStatusClass {
+		t.Errorf("Unexpected metrics: %+v", md.metrics)
+	}
+
+	ld.entries, md.metrics = nil, nil
+	_, _ = rt.RoundTrip(req.WithContext(WithoutInstrumentation(req.Context())))
+	if len(ld.entries) != 0 || len(md.metrics) != 0 {
+		t.Errorf("Expected opted out request not to be instrumented, got %+v %+v", ld.entries, md.metrics)
+	}
+}
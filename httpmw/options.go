@@ -0,0 +1,59 @@
+package httpmw
+
+import "github.com/Pacman29/observability/logger"
+
+type options struct {
+	requestIDHeader string
+	newRequestID    func() string
+	accessLog       bool
+	accessLogLevel  logger.Level
+	durationBuckets []float64
+}
+
+type Option func(o *options)
+
+func newOptions() *options {
+	return &options{
+		requestIDHeader: DefaultRequestIDHeader,
+		newRequestID:    newRequestID,
+		accessLog:       true,
+		accessLogLevel:  logger.InfoLevel,
+		durationBuckets: DefaultDurationBuckets,
+	}
+}
+
+// WithRequestIDHeader sets the header which carries the request ID, X-Request-ID by default.
+func WithRequestIDHeader(name string) Option {
+	return func(o *options) {
+		o.requestIDHeader = name
+	}
+}
+
+// WithRequestIDGenerator sets the generator of the request ID for the requests without the header,
+// random 16 bytes in hex by default.
+func WithRequestIDGenerator(f func() string) Option {
+	return func(o *options) {
+		o.newRequestID = f
+	}
+}
+
+// WithAccessLog enables the access log, enabled by default.
+func WithAccessLog(enabled bool) Option {
+	return func(o *options) {
+		o.accessLog = enabled
+	}
+}
+
+// WithAccessLogLevel sets the level of the access log, Info by default. Responses with 5xx status are logged as errors.
+func WithAccessLogLevel(l logger.Level) Option {
+	return func(o *options) {
+		o.accessLogLevel = l
+	}
+}
+
+// WithDurationBuckets sets the histogram buckets of the request duration in milliseconds, DefaultDurationBuckets by default.
+func WithDurationBuckets(buckets ...float64) Option {
+	return func(o *options) {
+		o.durationBuckets = buckets
+	}
+}
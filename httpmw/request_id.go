@@ -0,0 +1,37 @@
+package httpmw
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+const (
+	DefaultRequestIDHeader = "X-Request-ID"
+	// RequestIDTag is the logger tag which holds the request ID. The metrics don't read it unless it is allowed
+	// by metrics.WithSharedTags, it is a label of unbounded cardinality.
+	RequestIDTag = "request_id"
+)
+
+type key int
+
+const (
+	requestIDKey key = iota
+	skipKey
+)
+
+// RequestID returns the ID of the request served by the middleware.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+func withRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,143 @@
+package httpmw
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+	"github.com/Pacman29/observability/metrics"
+)
+
+const (
+	RequestsMetric         = "http_server_requests_total"
+	RequestsInFlightMetric = "http_server_requests_in_flight"
+	RequestDurationMetric  = "http_server_request_duration_ms"
+
+	AccessLogMessage = "http request"
+)
+
+// DefaultDurationBuckets are the histogram buckets of the duration metrics in milliseconds.
+var DefaultDurationBuckets = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// Metric labels. The route is the pattern of http.ServeMux, so the raw paths don't blow up the cardinality.
+const (
+	RouteLabel       = "route"
+	MethodLabel      = "method"
+	StatusClassLabel = "status_class"
+
+	unmatchedRoute = "unmatched"
+)
+
+// New returns the middleware which sets up the observability of the request:
+// the request and its ID are added to the logger context, the access log and RED metrics are written
+// and a panic of the handler is logged by Logger.Recover and answered with 500. http.ErrAbortHandler is panicked again.
+//
+// The route label is taken from r.Pattern, so the middleware must wrap http.ServeMux rather than be wrapped by it.
+// The in-flight gauge is labelled only by the method, the route is not known until the request is routed.
+func New(l logger.Logger, m metrics.Metrics, opts ...Option) func(next http.Handler) http.Handler {
+	o := newOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			// metrics of the middleware are written without the request ID, it would be a label of unbounded cardinality
+			metricsCtx := r.Context()
+
+			id := r.Header.Get(o.requestIDHeader)
+			if id == "" {
+				id = o.newRequestID()
+			}
+			w.Header().Set(o.requestIDHeader, id)
+
+			ctx := withRequestID(r.Context(), id)
+			ctx = l.WithTag(ctx, RequestIDTag, id)
+			r = r.WithContext(ctx)
+			ctx = l.WithRequest(ctx, withoutBody(r))
+			r = r.WithContext(ctx)
+
+			inFlight := metrics.WithTag(MethodLabel, r.Method)
+			m.Gauge(metricsCtx, RequestsInFlightMetric, 1, inFlight)
+			defer m.Gauge(metricsCtx, RequestsInFlightMetric, -1, inFlight)
+
+			rw := &responseWriter{ResponseWriter: w}
+			panicked, aborted := true, false
+			defer func() {
+				if panicked && rw.status == 0 {
+					if aborted {
+						// net/http drops the response, the status is only recorded
+						rw.status = http.StatusInternalServerError
+					} else {
+						rw.WriteHeader(http.StatusInternalServerError)
+					}
+				}
+				if rw.status == 0 {
+					rw.status = http.StatusOK
+				}
+				duration := time.Since(start)
+
+				route := r.Pattern
+				if route == "" {
+					route = unmatchedRoute
+				}
+				tags := metrics.WithTags(map[string]string{
+					RouteLabel:       route,
+					MethodLabel:      r.Method,
+					StatusClassLabel: statusClass(rw.status),
+				})
+				m.Increment(metricsCtx, RequestsMetric, 1, tags)
+				m.Duration(metricsCtx, RequestDurationMetric, duration, tags, metrics.WithBuckets(o.durationBuckets...))
+
+				if o.accessLog {
+					level := o.accessLogLevel
+					if rw.status >= http.StatusInternalServerError {
+						level = logger.ErrorLevel
+					}
+					l.Log(ctx, level, AccessLogMessage,
+						l.Field("method", r.Method),
+						l.Field("path", r.URL.Path),
+						l.Field(RouteLabel, r.Pattern),
+						l.Field("status", rw.status),
+						l.Field("size", rw.size),
+						l.Field("duration", duration),
+					)
+				}
+			}()
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+				// http.ErrAbortHandler aborts the response on purpose, it is passed on to net/http unreported
+				if p == http.ErrAbortHandler {
+					aborted = true
+					panic(p)
+				}
+				func() {
+					defer l.Recover(ctx)
+					panic(p)
+				}()
+			}()
+
+			next.ServeHTTP(rw, r)
+			panicked = false
+		})
+	}
+}
+
+// withoutBody returns the copy of r for the logs. The body is omitted, the drivers which render the request
+// would read the body the handler is going to read.
+func withoutBody(r *http.Request) *http.Request {
+	res := *r
+	res.Body = http.NoBody
+	res.GetBody = nil
+	res.ContentLength = 0
+	return &res
+}
+
+func statusClass(status int) string {
+	return strconv.Itoa(status/100) + "xx"
+}
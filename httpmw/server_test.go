@@ -0,0 +1,248 @@
+package httpmw
+
+import (
+	"context"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+	"github.com/Pacman29/observability/metrics"
+)
+
+type logEntry struct {
+	level  logger.Level
+	msg    string
+	tags   map[string]string
+	panic  any
+	fields map[string]any
+	caller *logger.Caller
+}
+
+type recordLogDriver struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (d *recordLogDriver) write(h logger.EventHandler, panicErr any) {
+	e := logEntry{
+		level:  h.Level(),
+		msg:    h.Msg(),
+		tags:   maps.Collect(h.Tags()),
+		panic:  panicErr,
+		fields: maps.Collect(h.Fields()),
+		caller: h.Caller(),
+	}
+	d.mu.Lock()
+	d.entries = append(d.entries, e)
+	d.mu.Unlock()
+}
+
+func (d *recordLogDriver) Trace(ctx context.Context, h logger.EventHandler)   { d.write(h, nil) }
+func (d *recordLogDriver) Debug(ctx context.Context, h logger.EventHandler)   { d.write(h, nil) }
+func (d *recordLogDriver) Warning(ctx context.Context, h logger.EventHandler) { d.write(h, nil) }
+func (d *recordLogDriver) Info(ctx context.Context, h logger.EventHandler)    { d.write(h, nil) }
+func (d *recordLogDriver) Error(ctx context.Context, h logger.EventHandler)   { d.write(h, nil) }
+func (d *recordLogDriver) Fatal(ctx context.Context, h logger.EventHandler)   { d.write(h, nil) }
+func (d *recordLogDriver) Flush(timeout time.Duration) error                  { return nil }
+func (d *recordLogDriver) Recover(err any, ctx context.Context, h logger.EventHandler) {
+	d.write(h, err)
+}
+
+type metric struct {
+	kind    string
+	key     string
+	value   float64
+	tags    map[string]string
+	buckets []float64
+}
+
+type recordMetricsDriver struct {
+	mu      sync.Mutex
+	metrics []metric
+}
+
+func (d *recordMetricsDriver) write(kind string, h metrics.EventHandler) {
+	d.mu.Lock()
+	d.metrics = append(d.metrics, metric{kind: kind, key: h.GetKey(), value: h.GetValue(), tags: h.GetTags(), buckets: h.GetBuckets()})
+	d.mu.Unlock()
+}
+
+func (d *recordMetricsDriver) Counter(ctx context.Context, h metrics.EventHandler) {
+	d.write("counter", h)
+}
+func (d *recordMetricsDriver) Increment(ctx context.Context, h metrics.EventHandler) {
+	d.write("counter", h)
+}
+func (d *recordMetricsDriver) Gauge(ctx context.Context, h metrics.EventHandler) { d.write("gauge", h) }
+func (d *recordMetricsDriver) Histogram(ctx context.Context, h metrics.EventHandler) {
+	d.write("histogram", h)
+}
+func (d *recordMetricsDriver) Timing(ctx context.Context, h metrics.EventHandler) {
+	d.write("histogram", h)
+}
+func (d *recordMetricsDriver) Duration(ctx context.Context, h metrics.EventHandler) {
+	d.write("histogram", h)
+}
+func (d *recordMetricsDriver) Flush() {}
+func (d *recordMetricsDriver) Close() {}
+
+func TestServer(t *testing.T) {
+	ld, md := &recordLogDriver{}, &recordMetricsDriver{}
+	l, m := logger.New(ld), metrics.New(md)
+
+	var handlerID string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		handlerID = RequestID(r.Context())
+		m.Increment(r.Context(), "orders_total", 1)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("hello"))
+	})
+	handler := New(l, m)(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set(DefaultRequestIDHeader, "abc")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if handlerID != "abc" || rec.Header().Get(DefaultRequestIDHeader) != "abc" {
+		t.Errorf("Expected request ID to be propagated, got %q %q", handlerID, rec.Header().Get(DefaultRequestIDHeader))
+	}
+
+	if len(ld.entries) != 1 {
+		t.Fatalf("Expected access log, got %v", ld.entries)
+	}
+	e := ld.entries[0]
+	if e.msg != AccessLogMessage || e.level != logger.InfoLevel || e.tags[RequestIDTag] != "abc" {
+		t.Errorf("Unexpected access log: %+v", e)
+	}
+
+	expected := map[string]string{RouteLabel: "GET /users/{id}", MethodLabel: http.MethodGet, StatusClassLabel: "2xx"}
+	var requests, durations int
+	for _, mt := range md.metrics {
+		if _, ok := mt.tags[RequestIDTag]; ok {
+			t.Errorf("Unexpected request ID label in %s", mt.key)
+		}
+		switch mt.key {
+		case RequestsMetric:
+			requests++
+			if !maps.Equal(mt.tags, expected) || mt.value != 1 {
+				t.Errorf("Unexpected requests metric: %+v", mt)
+			}
+		case RequestDurationMetric:
+			durations++
+			if !maps.Equal(mt.tags, expected) || !slices.Equal(mt.buckets, DefaultDurationBuckets) {
+				t.Errorf("Unexpected duration metric: %+v", mt)
+			}
+		}
+	}
+	if requests != 1 || durations != 1 || len(md.metrics) != 5 {
+		t.Errorf("Unexpected metrics: %+v", md.metrics)
+	}
+}
+
+func TestServerPanic(t *testing.T) {
+	ld, md := &recordLogDriver{}, &recordMetricsDriver{}
+	l, m := logger.New(ld, logger.WithCaller(true)), metrics.New(md)
+
+	handler := New(l, m)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500, got %d", rec.Code)
+	}
+	if rec.Header().Get(DefaultRequestIDHeader) == "" {
+		t.Error("Expected generated request ID")
+	}
+	if len(ld.entries) != 2 || ld.entries[0].panic != "boom" || ld.entries[1].level != logger.ErrorLevel {
+		t.Fatalf("Unexpected log entries: %+v", ld.entries)
+	}
+	if c := ld.entries[0].caller; c == nil || !strings.HasSuffix(c.File, "server_test.go") {
+		t.Errorf("Expected the location of the panic in the handler, got %+v", c)
+	}
+
+	var inFlight float64
+	for _, mt := range md.metrics {
+		switch mt.key {
+		case RequestsInFlightMetric:
+			inFlight += mt.value
+		case RequestsMetric:
+			if mt.tags[StatusClassLabel] != "5xx" || mt.tags[RouteLabel] != unmatchedRoute {
+				t.Errorf("Unexpected requests metric: %+v", mt)
+			}
+		}
+	}
+	if inFlight != 0 {
+		t.Errorf("Expected in-flight gauge to return to 0, got %v", inFlight)
+	}
+}
+
+func TestServerAbortHandler(t *testing.T) {
+	ld := &recordLogDriver{}
+	l, m := logger.New(ld), metrics.New(&recordMetricsDriver{})
+
+	handler := New(l, m)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	rec := httptest.NewRecorder()
+	func() {
+		defer func() {
+			if p := recover(); p != http.ErrAbortHandler {
+				t.Errorf("Expected http.ErrAbortHandler to be panicked again, got %v", p)
+			}
+		}()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	}()
+
+	if rec.Code == http.StatusInternalServerError {
+		t.Error("Expected no response to be written")
+	}
+	if len(ld.entries) != 1 || ld.entries[0].panic != nil || ld.entries[0].msg != AccessLogMessage {
+		t.Errorf("Expected only the access log, got %+v", ld.entries)
+	}
+}
+
+// bodyDriver reads the body of the request like the drivers which render it with curl.
+type bodyDriver struct {
+	recordLogDriver
+	body string
+}
+
+func (d *bodyDriver) Info(ctx context.Context, h logger.EventHandler) {
+	if req := h.Req(); req != nil && d.body == "" {
+		b, _ := io.ReadAll(req.Body)
+		d.body = string(b)
+	}
+}
+
+func TestServerRequestBody(t *testing.T) {
+	ld := &bodyDriver{}
+	l, m := logger.New(ld), metrics.New(&recordMetricsDriver{})
+
+	var body string
+	handler := New(l, m)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.Info(r.Context(), "before the body is read")
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader("payload")))
+
+	if body != "payload" {
+		t.Errorf("Expected the handler to read the body, got %q", body)
+	}
+	if ld.body != "" {
+		t.Errorf("Expected the logged request without the body, got %q", ld.body)
+	}
+}
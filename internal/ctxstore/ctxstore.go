@@ -0,0 +1,90 @@
+// Package ctxstore keeps the values of the context as immutable layers, it is shared by the logger fields
+// and the tags of obsctx.
+package ctxstore
+
+import (
+	"maps"
+	"slices"
+	"sync/atomic"
+)
+
+// Node is one layer of the values of the context. A layer is never modified after it is put into the context,
+// so adding a value is O(1) and the layers are shared by the child contexts.
+// The values are flattened into a map only when they are read, the map is cached in the layer.
+type Node[V any] struct {
+	parent *Node[V]
+	key    string
+	value  V
+	// batch is set if the layer adds several values
+	batch map[string]V
+
+	flat atomic.Pointer[map[string]V]
+}
+
+// With returns the layer which adds the value k on top of n, n may be nil.
+func (n *Node[V]) With(k string, v V) *Node[V] {
+	return &Node[V]{parent: n, key: k, value: v}
+}
+
+// WithAll returns the layer which adds the values of m on top of n, m is copied.
+func (n *Node[V]) WithAll(m map[string]V) *Node[V] {
+	batch := maps.Clone(m)
+	if batch == nil {
+		batch = map[string]V{}
+	}
+	return &Node[V]{parent: n, batch: batch}
+}
+
+// Get returns the value k of the newest layer which has it, the layers are not flattened.
+func (n *Node[V]) Get(k string) (V, bool) {
+	for c := n; c != nil; c = c.parent {
+		if m := c.flat.Load(); m != nil {
+			v, ok := (*m)[k]
+			return v, ok
+		}
+		if c.batch != nil {
+			if v, ok := c.batch[k]; ok {
+				return v, true
+			}
+		} else if c.key == k {
+			return c.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// All returns the values of all layers, the newer layers win. The result is shared and must not be modified.
+func (n *Node[V]) All() map[string]V {
+	if n == nil {
+		return nil
+	}
+	if m := n.flat.Load(); m != nil {
+		return *m
+	}
+
+	// only the layers above the closest flattened one are applied
+	var (
+		layers []*Node[V]
+		base   map[string]V
+	)
+	for c := n; c != nil; c = c.parent {
+		if m := c.flat.Load(); m != nil {
+			base = *m
+			break
+		}
+		layers = append(layers, c)
+	}
+
+	res := make(map[string]V, len(base)+len(layers))
+	maps.Copy(res, base)
+	for _, c := range slices.Backward(layers) {
+		if c.batch != nil {
+			maps.Copy(res, c.batch)
+		} else {
+			res[c.key] = c.value
+		}
+	}
+	n.flat.Store(&res)
+	return res
+}
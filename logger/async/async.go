@@ -0,0 +1,253 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+var (
+	ErrDrainTimeout = errors.New("async: queue is not drained in time")
+	ErrFlushTimeout = errors.New("async: no time is left to flush the wrapped driver")
+)
+
+type Driver interface {
+	logger.Driver
+	// Dropped returns the number of events dropped because of the overflow policy or because the driver is closed.
+	Dropped() uint64
+	// Close drains the queue, stops the workers and flushes the wrapped driver. The events written after Close
+	// are dropped, Fatal and Recover are still written synchronously.
+	Close(timeout time.Duration) error
+}
+
+type task struct {
+	ctx   context.Context
+	level logger.Level
+	event *logger.Event
+}
+
+type driver struct {
+	d       logger.Driver
+	o       *options
+	queue   chan task
+	dropped atomic.Uint64
+
+	mu      sync.Mutex
+	pending int
+	idle    chan struct{}
+
+	// closeMu is held for reading while an event is sent to the queue, so Close doesn't close the queue under a sender
+	closeMu sync.RWMutex
+	closed  bool
+	stopped chan struct{}
+}
+
+// NewAsyncDriver writes events to d from background workers through a bounded queue, so slow drivers
+// don't add latency to the caller. Each event is copied before it is queued.
+// Fatal and Recover are written synchronously after the queue is drained.
+// The workers run until Close, so the driver must be closed once it is not used anymore.
+func NewAsyncDriver(d logger.Driver, opts ...Option) Driver {
+	o := newOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	idle := make(chan struct{})
+	close(idle)
+
+	ad := &driver{
+		d:       d,
+		o:       o,
+		queue:   make(chan task, o.queueSize),
+		idle:    idle,
+		stopped: make(chan struct{}),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < max(o.workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ad.work()
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(ad.stopped)
+	}()
+	return ad
+}
+
+func (d *driver) work() {
+	for t := range d.queue {
+		logger.Dispatch(t.ctx, d.d, t.level, t.event)
+		d.done()
+	}
+}
+
+func (d *driver) add() {
+	d.mu.Lock()
+	if d.pending == 0 {
+		d.idle = make(chan struct{})
+	}
+	d.pending++
+	d.mu.Unlock()
+}
+
+func (d *driver) done() {
+	d.mu.Lock()
+	d.pending--
+	if d.pending == 0 {
+		close(d.idle)
+	}
+	d.mu.Unlock()
+}
+
+func (d *driver) drop(level logger.Level) {
+	d.done()
+	d.countDropped(level)
+}
+
+func (d *driver) countDropped(level logger.Level) {
+	d.dropped.Add(1)
+	if d.o.onDrop != nil {
+		d.o.onDrop(level)
+	}
+}
+
+// wait blocks until all queued events are written to the wrapped driver.
+func (d *driver) wait(timeout time.Duration) error {
+	d.mu.Lock()
+	idle := d.idle
+	d.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-idle:
+		return nil
+	case <-timer.C:
+		return ErrDrainTimeout
+	}
+}
+
+func (d *driver) enqueue(ctx context.Context, level logger.Level, h logger.EventHandler) {
+	d.closeMu.RLock()
+	defer d.closeMu.RUnlock()
+	if d.closed {
+		d.countDropped(level)
+		return
+	}
+
+	t := task{
+		ctx:   context.WithoutCancel(ctx),
+		level: level,
+		event: logger.Snapshot(h),
+	}
+	d.add()
+
+	switch d.o.policy {
+	case DropNewest:
+		select {
+		case d.queue <- t:
+		default:
+			d.drop(level)
+		}
+	case DropOldest:
+		for {
+			select {
+			case d.queue <- t:
+				return
+			default:
+			}
+			select {
+			case old := <-d.queue:
+				d.drop(old.level)
+			default:
+			}
+		}
+	default:
+		d.queue <- t
+	}
+}
+
+func (d *driver) Dropped() uint64 {
+	return d.dropped.Load()
+}
+
+func (d *driver) Trace(ctx context.Context, h logger.EventHandler) {
+	d.enqueue(ctx, logger.TraceLevel, h)
+}
+
+func (d *driver) Debug(ctx context.Context, h logger.EventHandler) {
+	d.enqueue(ctx, logger.DebugLevel, h)
+}
+
+func (d *driver) Info(ctx context.Context, h logger.EventHandler) {
+	d.enqueue(ctx, logger.InfoLevel, h)
+}
+
+func (d *driver) Warning(ctx context.Context, h logger.EventHandler) {
+	d.enqueue(ctx, logger.WarningLevel, h)
+}
+
+func (d *driver) Error(ctx context.Context, h logger.EventHandler) {
+	d.enqueue(ctx, logger.ErrorLevel, h)
+}
+
+func (d *driver) Fatal(ctx context.Context, h logger.EventHandler) {
+	_ = d.wait(d.o.drainTimeout)
+	d.d.Fatal(ctx, h)
+}
+
+func (d *driver) Recover(err any, ctx context.Context, h logger.EventHandler) {
+	_ = d.wait(d.o.drainTimeout)
+	d.d.Recover(err, ctx, h)
+}
+
+// Flush waits until the queue is drained and then flushes the wrapped driver with the rest of the timeout.
+func (d *driver) Flush(timeout time.Duration) error {
+	start := time.Now()
+	if err := d.wait(timeout); err != nil {
+		return err
+	}
+	return d.flushRest(start, timeout)
+}
+
+// flushRest flushes the wrapped driver with the time which is left of timeout since start.
+func (d *driver) flushRest(start time.Time, timeout time.Duration) error {
+	rest := timeout - time.Since(start)
+	if rest <= 0 {
+		return ErrFlushTimeout
+	}
+	return d.d.Flush(rest)
+}
+
+func (d *driver) Close(timeout time.Duration) error {
+	start := time.Now()
+
+	d.closeMu.Lock()
+	if d.closed {
+		d.closeMu.Unlock()
+		return nil
+	}
+	d.closed = true
+	close(d.queue)
+	d.closeMu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	// the workers exit once the queue is drained
+	select {
+	case <-d.stopped:
+	case <-timer.C:
+		return ErrDrainTimeout
+	}
+	return d.flushRest(start, timeout)
+}
@@ -0,0 +1,176 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+type slowDriver struct {
+	mu      sync.Mutex
+	release chan struct{}
+	msgs    []string
+}
+
+func (d *slowDriver) write(h logger.EventHandler) {
+	<-d.release
+	d.mu.Lock()
+	d.msgs = append(d.msgs, h.Msg())
+	d.mu.Unlock()
+}
+
+func (d *slowDriver) Trace(ctx context.Context, h logger.EventHandler)   { d.write(h) }
+func (d *slowDriver) Debug(ctx context.Context, h logger.EventHandler)   { d.write(h) }
+func (d *slowDriver) Warning(ctx context.Context, h logger.EventHandler) { d.write(h) }
+func (d *slowDriver) Info(ctx context.Context, h logger.EventHandler)    { d.write(h) }
+func (d *slowDriver) Error(ctx context.Context, h logger.EventHandler)   { d.write(h) }
+func (d *slowDriver) Fatal(ctx context.Context, h logger.EventHandler)   { d.write(h) }
+func (d *slowDriver) Flush(timeout time.Duration) error                  { return nil }
+func (d *slowDriver) Recover(err any, ctx context.Context, h logger.EventHandler) {
+	d.write(h)
+}
+
+func TestAsyncDropOldest(t *testing.T) {
+	inner := &slowDriver{release: make(chan struct{})}
+	d := NewAsyncDriver(inner, WithQueueSize(2), WithOverflowPolicy(DropOldest))
+	l := logger.New(d)
+
+	ctx := context.Background()
+	l.Info(ctx, "blocks worker")
+	// wait until the worker takes the first event from the queue
+	for len(d.(*driver).queue) != 0 {
+		time.Sleep(time.Millisecond)
+	}
+	l.Info(ctx, "dropped")
+	l.Info(ctx, "second")
+	l.Info(ctx, "third")
+
+	if err := d.Flush(10 * time.Millisecond); err == nil {
+		t.Error("Expected flush to time out while worker is blocked")
+	}
+
+	close(inner.release)
+	if err := d.Flush(time.Second); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	expected := []string{"blocks worker", "second", "third"}
+	if len(inner.msgs) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, inner.msgs)
+	}
+	for i := range expected {
+		if inner.msgs[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, inner.msgs)
+		}
+	}
+	if d.Dropped() != 1 {
+		t.Errorf("Expected 1 dropped event, got %d", d.Dropped())
+	}
+}
+
+// waitTaken waits until the worker takes the queued events.
+func waitTaken(d Driver) {
+	for len(d.(*driver).queue) != 0 {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAsyncDropNewest(t *testing.T) {
+	inner := &slowDriver{release: make(chan struct{})}
+	d := NewAsyncDriver(inner, WithQueueSize(1), WithOverflowPolicy(DropNewest))
+	l := logger.New(d)
+
+	ctx := context.Background()
+	l.Info(ctx, "blocks worker")
+	waitTaken(d)
+	l.Info(ctx, "queued")
+	l.Info(ctx, "dropped")
+
+	close(inner.release)
+	if err := d.Close(time.Second); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	if len(inner.msgs) != 2 || inner.msgs[0] != "blocks worker" || inner.msgs[1] != "queued" {
+		t.Errorf("Unexpected messages: %v", inner.msgs)
+	}
+	if d.Dropped() != 1 {
+		t.Errorf("Expected 1 dropped event, got %d", d.Dropped())
+	}
+}
+
+func TestAsyncBlock(t *testing.T) {
+	inner := &slowDriver{release: make(chan struct{})}
+	d := NewAsyncDriver(inner, WithQueueSize(1), WithOverflowPolicy(Block))
+	l := logger.New(d)
+
+	ctx := context.Background()
+	l.Info(ctx, "blocks worker")
+	waitTaken(d)
+	l.Info(ctx, "queued")
+
+	written := make(chan struct{})
+	go func() {
+		l.Info(ctx, "waits for space")
+		close(written)
+	}()
+
+	select {
+	case <-written:
+		t.Fatal("Expected the producer to block while the queue is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(inner.release)
+	<-written
+	if err := d.Close(time.Second); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	if len(inner.msgs) != 3 || inner.msgs[2] != "waits for space" {
+		t.Errorf("Unexpected messages: %v", inner.msgs)
+	}
+	if d.Dropped() != 0 {
+		t.Errorf("Expected no dropped events, got %d", d.Dropped())
+	}
+}
+
+func TestAsyncClose(t *testing.T) {
+	inner := &slowDriver{release: make(chan struct{})}
+	d := NewAsyncDriver(inner, WithWorkers(2))
+	l := logger.New(d)
+
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		l.Info(ctx, "queued")
+	}
+	if err := d.Close(10 * time.Millisecond); err != ErrDrainTimeout {
+		t.Errorf("Expected drain timeout while the workers are blocked, got %v", err)
+	}
+
+	close(inner.release)
+	<-d.(*driver).stopped
+	if len(inner.msgs) != 10 {
+		t.Errorf("Expected the queue to be drained, got %d events", len(inner.msgs))
+	}
+
+	l.Info(ctx, "after close")
+	if len(inner.msgs) != 10 || d.Dropped() != 1 {
+		t.Errorf("Expected the event after Close to be dropped, got %v and %d dropped", inner.msgs, d.Dropped())
+	}
+	if err := d.Close(time.Second); err != nil {
+		t.Errorf("Expected repeated Close to succeed, got %v", err)
+	}
+}
+
+func TestAsyncFlushTimeout(t *testing.T) {
+	d := NewAsyncDriver(&slowDriver{release: make(chan struct{})}).(*driver)
+	defer d.Close(time.Second)
+
+	if err := d.flushRest(time.Now().Add(-time.Second), time.Millisecond); err != ErrFlushTimeout {
+		t.Errorf("Expected flush timeout, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package async
+
+import (
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+type OverflowPolicy int
+
+const (
+	// Block waits for free space in the queue.
+	Block OverflowPolicy = iota
+	// DropNewest drops the event which doesn't fit into the queue.
+	DropNewest
+	// DropOldest drops the oldest queued event to free space for the new one.
+	DropOldest
+)
+
+type options struct {
+	queueSize    int
+	workers      int
+	policy       OverflowPolicy
+	onDrop       func(level logger.Level)
+	drainTimeout time.Duration
+}
+
+type Option func(o *options)
+
+func newOptions() *options {
+	return &options{
+		queueSize:    1024,
+		workers:      1,
+		policy:       Block,
+		onDrop:       nil,
+		drainTimeout: 5 * time.Second,
+	}
+}
+
+func WithQueueSize(n int) Option {
+	return func(o *options) {
+		o.queueSize = n
+	}
+}
+
+// WithWorkers sets the number of goroutines writing to the wrapped driver.
+// The order of the events is kept only with a single worker.
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		o.workers = n
+	}
+}
+
+func WithOverflowPolicy(p OverflowPolicy) Option {
+	return func(o *options) {
+		o.policy = p
+	}
+}
+
+// WithOnDrop sets the callback which is called for each dropped event, e.g. to count them in metrics.
+func WithOnDrop(f func(level logger.Level)) Option {
+	return func(o *options) {
+		o.onDrop = f
+	}
+}
+
+// WithDrainTimeout sets how long Fatal and Recover wait for the queued events before they are written.
+func WithDrainTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.drainTimeout = d
+	}
+}
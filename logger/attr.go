@@ -0,0 +1,164 @@
+package logger
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+type Kind uint8
+
+const (
+	KindAny Kind = iota
+	KindString
+	KindInt64
+	KindUint64
+	KindFloat64
+	KindBool
+	KindDuration
+	KindTime
+	KindStringer
+)
+
+// Attr is a typed field of the event. Unlike Logger.Field it keeps the numbers, strings and times without boxing them
+// into any, so the drivers which know Attr (zap, slog) write them without reflection. Logger.LogAttrs allocates
+// nothing but the variadic slice, an Attr passed in the args of other methods is boxed into any.
+type Attr struct {
+	Key  string
+	kind Kind
+	num  uint64
+	str  string
+	// any holds the value of KindAny and KindStringer, the location of KindTime or the time itself
+	// if it doesn't fit into int64 nanoseconds
+	any any
+}
+
+func String(k string, v string) Attr {
+	return Attr{Key: k, kind: KindString, str: v}
+}
+
+func Int(k string, v int) Attr {
+	return Int64(k, int64(v))
+}
+
+func Int64(k string, v int64) Attr {
+	return Attr{Key: k, kind: KindInt64, num: uint64(v)}
+}
+
+func Uint64(k string, v uint64) Attr {
+	return Attr{Key: k, kind: KindUint64, num: v}
+}
+
+func Float64(k string, v float64) Attr {
+	return Attr{Key: k, kind: KindFloat64, num: math.Float64bits(v)}
+}
+
+func Bool(k string, v bool) Attr {
+	var n uint64
+	if v {
+		n = 1
+	}
+	return Attr{Key: k, kind: KindBool, num: n}
+}
+
+func Duration(k string, v time.Duration) Attr {
+	return Attr{Key: k, kind: KindDuration, num: uint64(v)}
+}
+
+// Time keeps the wall clock and the location of v, the monotonic clock is dropped.
+func Time(k string, v time.Time) Attr {
+	// UnixNano is defined only for the years 1678-2262, the zero time among others is kept as is
+	if v.IsZero() || v.Before(minUnixNano) || v.After(maxUnixNano) {
+		return Attr{Key: k, kind: KindTime, any: v.Round(0)}
+	}
+	return Attr{Key: k, kind: KindTime, num: uint64(v.UnixNano()), any: v.Location()}
+}
+
+var (
+	minUnixNano = time.Unix(0, math.MinInt64)
+	maxUnixNano = time.Unix(0, math.MaxInt64)
+)
+
+// Stringer calls v.String() only when the event is written.
+func Stringer(k string, v fmt.Stringer) Attr {
+	return Attr{Key: k, kind: KindStringer, any: v}
+}
+
+func Any(k string, v any) Attr {
+	return Attr{Key: k, kind: KindAny, any: v}
+}
+
+func (a Attr) Kind() Kind {
+	return a.kind
+}
+
+// String returns the value of KindString, the values of other kinds are formatted.
+func (a Attr) String() string {
+	if a.kind == KindString {
+		return a.str
+	}
+	return fmt.Sprint(a.Value())
+}
+
+func (a Attr) Int64() int64 {
+	return int64(a.num)
+}
+
+func (a Attr) Uint64() uint64 {
+	return a.num
+}
+
+func (a Attr) Float64() float64 {
+	return math.Float64frombits(a.num)
+}
+
+func (a Attr) Bool() bool {
+	return a.num == 1
+}
+
+func (a Attr) Duration() time.Duration {
+	return time.Duration(a.num)
+}
+
+func (a Attr) Time() time.Time {
+	if t, ok := a.any.(time.Time); ok {
+		return t
+	}
+	t := time.Unix(0, int64(a.num))
+	if loc, ok := a.any.(*time.Location); ok {
+		t = t.In(loc)
+	}
+	return t
+}
+
+func (a Attr) Stringer() fmt.Stringer {
+	s, _ := a.any.(fmt.Stringer)
+	return s
+}
+
+// Any returns the value of KindAny.
+func (a Attr) Any() any {
+	return a.any
+}
+
+// Value returns the value of any kind boxed into any, e.g. for the drivers which don't know Attr.
+func (a Attr) Value() any {
+	switch a.kind {
+	case KindString:
+		return a.str
+	case KindInt64:
+		return a.Int64()
+	case KindUint64:
+		return a.num
+	case KindFloat64:
+		return a.Float64()
+	case KindBool:
+		return a.Bool()
+	case KindDuration:
+		return a.Duration()
+	case KindTime:
+		return a.Time()
+	default:
+		return a.any
+	}
+}
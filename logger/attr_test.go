@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"context"
+	"maps"
+	"testing"
+	"time"
+)
+
+type nopDriver struct {
+	recordDriver
+}
+
+func (d *nopDriver) Info(ctx context.Context, h EventHandler) {}
+
+func TestAttr(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6, time.FixedZone("test", 3600))
+	attrs := []struct {
+		attr     Attr
+		kind     Kind
+		expected any
+	}{
+		{String("s", "v"), KindString, "v"},
+		{Int("i", -1), KindInt64, int64(-1)},
+		{Uint64("u", 1), KindUint64, uint64(1)},
+		{Float64("f", 1.5), KindFloat64, 1.5},
+		{Bool("b", true), KindBool, true},
+		{Duration("d", time.Second), KindDuration, time.Second},
+		{Any("a", "any"), KindAny, "any"},
+		{Stringer("st", DebugLevel), KindStringer, DebugLevel},
+	}
+	for _, tt := range attrs {
+		if tt.attr.Kind() != tt.kind || tt.attr.Value() != tt.expected {
+			t.Errorf("%s: expected %v %v, got %v %v", tt.attr.Key, tt.kind, tt.expected, tt.attr.Kind(), tt.attr.Value())
+		}
+	}
+
+	if got := Time("t", now).Time(); !got.Equal(now) || got.Location() != now.Location() {
+		t.Errorf("Expected %v, got %v", now, got)
+	}
+	for _, tm := range []time.Time{{}, time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(1000, 1, 1, 0, 0, 0, 0, now.Location())} {
+		if got := Time("t", tm).Time(); !got.Equal(tm) || got.Location() != tm.Location() {
+			t.Errorf("Expected %v, got %v", tm, got)
+		}
+	}
+	if Int("i", 42).String() != "42" {
+		t.Errorf("Expected formatted value, got %q", Int("i", 42).String())
+	}
+}
+
+func TestAttrFields(t *testing.T) {
+	d := &fieldsDriver{}
+	l := New(d, WithRedactor(NewRedactor(RedactKeys(RedactMask, "password"))))
+
+	ctx := l.WithField(context.Background(), "id", "from ctx")
+	l.Info(ctx, "msg", Int("id", 1), String("password", "qwerty"), Int("id", 2))
+
+	if len(d.fields) != 2 || d.fields["id"] != int64(2) || d.fields["password"] != MaskedValue {
+		t.Errorf("Unexpected fields: %v", d.fields)
+	}
+}
+
+// raceEnabled is set under the race detector, sync.Pool drops objects randomly with it.
+var raceEnabled bool
+
+func TestLogAttrsAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool doesn't keep objects under the race detector")
+	}
+	// the concrete type is used, the call through the interface allocates the variadic slice
+	l := New(&nopDriver{}, poolOptions...).(*logger)
+	ctx := l.WithField(context.Background(), "component", "db")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		l.LogAttrs(ctx, InfoLevel, "msg", String("table", "users"), Int("rows", 10), Duration("took", time.Millisecond))
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
+
+// poolOptions make the pools keep the maps and slices of the events, nothing is kept with the zero capacities.
+var poolOptions = []Option{
+	WithFieldsMapPoolSaveCapacity(20),
+	WithTagsMapPoolSaveCapacity(20),
+	WithArgsArrayPoolSaveCapacity(20),
+}
+
+func BenchmarkLogAttrs(b *testing.B) {
+	l := New(&nopDriver{}, poolOptions...)
+	ctx := l.WithField(context.Background(), "component", "db")
+
+	b.Run("attrs", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			l.LogAttrs(ctx, InfoLevel, "msg", String("table", "users"), Int("rows", i), Duration("took", time.Millisecond))
+		}
+	})
+	b.Run("fields", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			l.Info(ctx, "msg", l.Field("table", "users"), l.Field("rows", i), l.Field("took", time.Millisecond))
+		}
+	})
+}
+
+type snapshotDriver struct {
+	recordDriver
+	event *Event
+}
+
+func (d *snapshotDriver) Info(ctx context.Context, h EventHandler) {
+	d.event = Snapshot(h)
+}
+
+func TestSnapshotAttrs(t *testing.T) {
+	d := &snapshotDriver{}
+	l := New(d)
+
+	l.LogAttrs(l.WithField(context.Background(), "component", "db"), InfoLevel, "msg", Int("rows", 10), Duration("took", time.Second))
+	d.event.SetField("rows", "overridden")
+
+	kinds := map[string]Kind{}
+	for a := range d.event.Attrs() {
+		kinds[a.Key] = a.Kind()
+	}
+	if len(kinds) != 3 || kinds["component"] != KindAny || kinds["took"] != KindDuration || kinds["rows"] != KindAny {
+		t.Errorf("Expected the typed attrs to be kept, got %v", kinds)
+	}
+	if fields := maps.Collect(d.event.Fields()); fields["took"] != time.Second || fields["rows"] != "overridden" {
+		t.Errorf("Unexpected fields: %v", fields)
+	}
+}
@@ -0,0 +1,119 @@
+package buffer
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+type key int
+
+const bufferKey key = iota
+
+const DefaultSize = 100
+
+// WithBuffer attaches a buffer for at most size events to ctx. Low level events logged with the context
+// are held back until an Error, Fatal or Recover happens with the same context, then they are written
+// before it in the original order. The returned function releases the buffer and discards its events,
+// it should be called when the request is finished. DefaultSize is used if size is not positive.
+//
+// Note that events below the logger level never reach the driver, so the logger level has to be lowered
+// for the request (see logger.Logger.WithLevel) to buffer Debug and Trace events.
+func WithBuffer(ctx context.Context, size int) (context.Context, func()) {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	r := newRing(size)
+	return context.WithValue(ctx, bufferKey, r), r.close
+}
+
+// NewMiddleware attaches the buffer to the context of each request and releases it when the request is served.
+func NewMiddleware(size int) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, release := WithBuffer(r.Context(), size)
+			defer release()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func getBuffer(ctx context.Context) *ring {
+	if r, ok := ctx.Value(bufferKey).(*ring); ok && r != nil {
+		return r
+	}
+	return nil
+}
+
+type driver struct {
+	d logger.Driver
+	o *options
+}
+
+func NewBufferDriver(d logger.Driver, opts ...Option) logger.Driver {
+	o := newOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &driver{
+		d: d,
+		o: o,
+	}
+}
+
+func (d *driver) hold(ctx context.Context, level logger.Level, h logger.EventHandler) {
+	if level <= d.o.maxLevel {
+		if r := getBuffer(ctx); r != nil && r.push(entry{ctx: ctx, level: level, event: logger.Snapshot(h)}) {
+			return
+		}
+	}
+	logger.Dispatch(ctx, d.d, level, h)
+}
+
+func (d *driver) flushBuffer(ctx context.Context) {
+	r := getBuffer(ctx)
+	if r == nil {
+		return
+	}
+	for _, e := range r.drain() {
+		logger.Dispatch(e.ctx, d.d, e.level, e.event)
+	}
+}
+
+func (d *driver) Trace(ctx context.Context, h logger.EventHandler) {
+	d.hold(ctx, logger.TraceLevel, h)
+}
+
+func (d *driver) Debug(ctx context.Context, h logger.EventHandler) {
+	d.hold(ctx, logger.DebugLevel, h)
+}
+
+func (d *driver) Info(ctx context.Context, h logger.EventHandler) {
+	d.hold(ctx, logger.InfoLevel, h)
+}
+
+func (d *driver) Warning(ctx context.Context, h logger.EventHandler) {
+	d.hold(ctx, logger.WarningLevel, h)
+}
+
+func (d *driver) Error(ctx context.Context, h logger.EventHandler) {
+	d.flushBuffer(ctx)
+	d.d.Error(ctx, h)
+}
+
+func (d *driver) Fatal(ctx context.Context, h logger.EventHandler) {
+	d.flushBuffer(ctx)
+	d.d.Fatal(ctx, h)
+}
+
+func (d *driver) Flush(timeout time.Duration) error {
+	return d.d.Flush(timeout)
+}
+
+func (d *driver) Recover(err any, ctx context.Context, h logger.EventHandler) {
+	d.flushBuffer(ctx)
+	d.d.Recover(err, ctx, h)
+}
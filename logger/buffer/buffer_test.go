@@ -0,0 +1,80 @@
+package buffer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+type recordDriver struct {
+	msgs []string
+}
+
+func (d *recordDriver) Trace(ctx context.Context, h logger.EventHandler) {
+	d.msgs = append(d.msgs, h.Msg())
+}
+func (d *recordDriver) Debug(ctx context.Context, h logger.EventHandler) {
+	d.msgs = append(d.msgs, h.Msg())
+}
+func (d *recordDriver) Warning(ctx context.Context, h logger.EventHandler) {
+	d.msgs = append(d.msgs, h.Msg())
+}
+func (d *recordDriver) Info(ctx context.Context, h logger.EventHandler) {
+	d.msgs = append(d.msgs, h.Msg())
+}
+func (d *recordDriver) Error(ctx context.Context, h logger.EventHandler) {
+	d.msgs = append(d.msgs, h.Msg())
+}
+func (d *recordDriver) Fatal(ctx context.Context, h logger.EventHandler) {
+	d.msgs = append(d.msgs, h.Msg())
+}
+func (d *recordDriver) Flush(timeout time.Duration) error { return nil }
+func (d *recordDriver) Recover(err any, ctx context.Context, h logger.EventHandler) {
+	d.msgs = append(d.msgs, "panic")
+}
+
+func TestBufferFlushOnError(t *testing.T) {
+	d := &recordDriver{}
+	l := logger.New(NewBufferDriver(d))
+
+	ctx, release := WithBuffer(context.Background(), 2)
+	defer release()
+
+	l.Debug(ctx, "dropped by ring")
+	l.Debug(ctx, "first")
+	l.Info(ctx, "second")
+	l.Warning(ctx, "warning")
+	if len(d.msgs) != 1 || d.msgs[0] != "warning" {
+		t.Fatalf("Expected only warning to be written, got %v", d.msgs)
+	}
+
+	l.Error(ctx, "error", errors.New("failed"))
+	expected := []string{"warning", "first", "second", "error"}
+	if len(d.msgs) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, d.msgs)
+	}
+	for i := range expected {
+		if d.msgs[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, d.msgs)
+		}
+	}
+}
+
+func TestBufferDiscardOnRelease(t *testing.T) {
+	d := &recordDriver{}
+	l := logger.New(NewBufferDriver(d))
+
+	ctx, release := WithBuffer(context.Background(), 10)
+	l.Info(ctx, "discarded")
+	release()
+
+	l.Info(ctx, "after release")
+	l.Info(context.Background(), "without buffer")
+
+	if len(d.msgs) != 2 || d.msgs[0] != "after release" || d.msgs[1] != "without buffer" {
+		t.Errorf("Unexpected messages: %v", d.msgs)
+	}
+}
@@ -0,0 +1,23 @@
+package buffer
+
+import "github.com/Pacman29/observability/logger"
+
+type options struct {
+	maxLevel logger.Level
+}
+
+type Option func(o *options)
+
+func newOptions() *options {
+	return &options{
+		maxLevel: logger.InfoLevel,
+	}
+}
+
+// WithMaxLevel sets the highest level which is held back in the buffer, Info by default.
+// Events above it are written immediately, Error and Fatal always flush the buffer.
+func WithMaxLevel(l logger.Level) Option {
+	return func(o *options) {
+		o.maxLevel = l
+	}
+}
@@ -0,0 +1,69 @@
+package buffer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+type entry struct {
+	ctx   context.Context
+	level logger.Level
+	event *logger.Event
+}
+
+type ring struct {
+	mu      sync.Mutex
+	entries []entry
+	start   int
+	size    int
+	closed  bool
+}
+
+func newRing(size int) *ring {
+	return &ring{
+		entries: make([]entry, size),
+	}
+}
+
+// push adds the entry to the buffer, the oldest one is overwritten when the buffer is full.
+// It returns false if the buffer has been already released.
+func (r *ring) push(e entry) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return false
+	}
+	r.entries[(r.start+r.size)%len(r.entries)] = e
+	if r.size < len(r.entries) {
+		r.size++
+	} else {
+		r.start = (r.start + 1) % len(r.entries)
+	}
+	return true
+}
+
+// drain returns the buffered entries in the order they were added and empties the buffer.
+func (r *ring) drain() []entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := make([]entry, 0, r.size)
+	for i := 0; i < r.size; i++ {
+		idx := (r.start + i) % len(r.entries)
+		res = append(res, r.entries[idx])
+		r.entries[idx] = entry{}
+	}
+	r.start, r.size = 0, 0
+	return res
+}
+
+func (r *ring) close() {
+	r.mu.Lock()
+	r.closed = true
+	clear(r.entries)
+	r.start, r.size = 0, 0
+	r.mu.Unlock()
+}
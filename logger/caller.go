@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"runtime"
+	"strings"
+)
+
+// callerSkip is the number of frames between runtime.Callers and the user's code:
+// runtime.Callers, logger.caller and the logger method itself.
+const callerSkip = 3
+
+const maxStackDepth = 64
+
+type Frame struct {
+	PC       uintptr `json:"-"`
+	Function string  `json:"func"`
+	File     string  `json:"file"`
+	Line     int     `json:"line"`
+}
+
+// Caller is the location of the user's code which has logged the event.
+// Stack holds the whole stack starting from the location, it is captured only if enabled by WithCallerStack.
+type Caller struct {
+	Frame
+	Stack []Frame
+}
+
+func (l *logger) caller(skip int) *Caller {
+	if !l.o.caller {
+		return nil
+	}
+
+	depth := 1
+	if l.o.callerStack {
+		depth = maxStackDepth
+	}
+	pcs := make([]uintptr, depth)
+	n := runtime.Callers(skip, pcs)
+	if n == 0 {
+		return nil
+	}
+
+	frames := Frames(pcs[:n])
+	c := &Caller{Frame: frames[0]}
+	if l.o.callerStack {
+		c.Stack = frames
+	}
+	return c
+}
+
+// panicCaller returns the location of the panic: the first frame after the runtime panic functions.
+// The outermost panic of the stack is taken, so a value recovered and panicked again by a deferred function
+// keeps the location of the original panic.
+func (l *logger) panicCaller(skip int) *Caller {
+	if !l.o.caller {
+		return nil
+	}
+
+	pcs := make([]uintptr, maxStackDepth)
+	frames := Frames(pcs[:runtime.Callers(skip, pcs)])
+	for i := len(frames) - 1; i >= 0; i-- {
+		if frames[i].Function != "runtime.gopanic" {
+			continue
+		}
+		for i < len(frames) && strings.HasPrefix(frames[i].Function, "runtime.") {
+			i++
+		}
+		frames = frames[i:]
+		break
+	}
+	if len(frames) == 0 {
+		return nil
+	}
+
+	c := &Caller{Frame: frames[0]}
+	if l.o.callerStack {
+		c.Stack = frames
+	}
+	return c
+}
+
+// Frames resolves program counters returned by runtime.Callers.
+func Frames(pcs []uintptr) []Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	res := make([]Frame, 0, len(pcs))
+	frames := runtime.CallersFrames(pcs)
+	for {
+		f, more := frames.Next()
+		res = append(res, Frame{
+			PC:       f.PC,
+			Function: f.Function,
+			File:     f.File,
+			Line:     f.Line,
+		})
+		if !more {
+			break
+		}
+	}
+	return res
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+type callerDriver struct {
+	recordDriver
+	caller *Caller
+}
+
+func (d *callerDriver) Info(ctx context.Context, h EventHandler) {
+	d.caller = h.Caller()
+}
+
+func (d *callerDriver) Recover(err any, ctx context.Context, h EventHandler) {
+	d.caller = h.Caller()
+}
+
+func TestCaller(t *testing.T) {
+	d := &callerDriver{}
+	l := New(d, WithCaller(true))
+
+	_, _, line, _ := runtime.Caller(0)
+	l.Info(context.Background(), "msg")
+
+	if d.caller == nil {
+		t.Fatal("Expected caller to be captured")
+	}
+	if !strings.HasSuffix(d.caller.File, "caller_test.go") || d.caller.Line != line+1 {
+		t.Errorf("Unexpected caller location %s:%d", d.caller.File, d.caller.Line)
+	}
+	if !strings.HasSuffix(d.caller.Function, "TestCaller") {
+		t.Errorf("Unexpected caller function %s", d.caller.Function)
+	}
+	if d.caller.Stack != nil {
+		t.Error("Expected stack not to be captured")
+	}
+}
+
+func TestPanicCaller(t *testing.T) {
+	d := &callerDriver{}
+	l := New(d, WithCallerStack(true))
+
+	var line int
+	func() {
+		defer l.Recover(context.Background())
+		_, _, line, _ = runtime.Caller(0)
+		panic("test")
+	}()
+
+	if d.caller == nil {
+		t.Fatal("Expected caller to be captured")
+	}
+	if !strings.HasSuffix(d.caller.File, "caller_test.go") || d.caller.Line != line+1 {
+		t.Errorf("Unexpected panic location %s:%d", d.caller.File, d.caller.Line)
+	}
+	if len(d.caller.Stack) == 0 {
+		t.Error("Expected stack to be captured")
+	}
+}
@@ -0,0 +1,79 @@
+package logger
+
+import "maps"
+
+// LoggerNameKey is the field which holds the dotted name of the logger set by Named.
+const LoggerNameKey = "logger"
+
+// bindings are the data bound to the child logger by With and Named.
+type bindings struct {
+	name   string
+	fields map[string]any
+	tags   map[string]string
+	// args are errors and positional args, they are resolved with the event args
+	args []any
+}
+
+func (b *bindings) clone() *bindings {
+	if b == nil {
+		return &bindings{
+			fields: map[string]any{},
+			tags:   map[string]string{},
+		}
+	}
+	return &bindings{
+		name:   b.name,
+		fields: maps.Clone(b.fields),
+		tags:   maps.Clone(b.tags),
+		args:   append([]any(nil), b.args...),
+	}
+}
+
+func (b *bindings) resolveArgs(args ...any) {
+	for _, arg := range args {
+		switch argument := arg.(type) {
+		case *field:
+			b.fields[argument.k] = argument.v
+		case *tag:
+			b.tags[argument.k] = argument.v
+		case Attr:
+			b.fields[argument.Key] = argument.Value()
+		default:
+			b.args = append(b.args, arg)
+		}
+	}
+}
+
+func (h *logEventHandler) resolveBindings(b *bindings) {
+	if b == nil {
+		return
+	}
+	if b.name != "" {
+		h.fields[LoggerNameKey] = b.name
+	}
+	for k, v := range b.fields {
+		h.fields[k] = v
+	}
+	for k, v := range b.tags {
+		h.tags[k] = v
+	}
+	h.resolveArgs(b.args...)
+}
+
+func (l *logger) With(args ...any) Logger {
+	child := *l
+	child.bound = l.bound.clone()
+	child.bound.resolveArgs(args...)
+	return &child
+}
+
+func (l *logger) Named(name string) Logger {
+	child := *l
+	child.bound = l.bound.clone()
+	if child.bound.name == "" {
+		child.bound.name = name
+	} else {
+		child.bound.name += "." + name
+	}
+	return &child
+}
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"context"
+	"testing"
+)
+
+type fieldsDriver struct {
+	recordDriver
+	fields map[string]any
+	tags   map[string]string
+}
+
+func (d *fieldsDriver) Info(ctx context.Context, h EventHandler) {
+	d.fields = map[string]any{}
+	for k, v := range h.Fields() {
+		d.fields[k] = v
+	}
+	d.tags = map[string]string{}
+	for k, v := range h.Tags() {
+		d.tags[k] = v
+	}
+}
+
+func TestChildLogger(t *testing.T) {
+	d := &fieldsDriver{}
+	l := New(d)
+
+	child := l.Named("repository").With(l.Field("table", "users"), l.Tag("component", "db")).Named("users")
+	ctx := l.WithField(context.Background(), "table", "from ctx")
+	child.Info(ctx, "msg")
+
+	if d.fields[LoggerNameKey] != "repository.users" {
+		t.Errorf("Unexpected logger name: %v", d.fields[LoggerNameKey])
+	}
+	if d.fields["table"] != "from ctx" {
+		t.Errorf("Expected context field to override the bound one, got %v", d.fields["table"])
+	}
+	if d.tags["component"] != "db" {
+		t.Errorf("Unexpected tags: %v", d.tags)
+	}
+
+	l.Info(ctx, "parent")
+	if _, ok := d.fields[LoggerNameKey]; ok || len(d.tags) != 0 {
+		t.Errorf("Expected parent logger to be unaffected, got %v %v", d.fields, d.tags)
+	}
+}
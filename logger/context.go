@@ -4,29 +4,41 @@ import (
 	"context"
 	"maps"
 	"net/http"
+
+	"github.com/Pacman29/observability/internal/ctxstore"
+	"github.com/Pacman29/observability/obsctx"
 )
 
 type key int
 
 const (
 	fieldKey key = iota
-	tagKey
 	errorKey
 	requestKey
+	levelKey
 )
 
+func getFieldsNode(ctx context.Context) *ctxstore.Node[any] {
+	n, _ := ctx.Value(fieldKey).(*ctxstore.Node[any])
+	return n
+}
+
+// getFields returns the fields of ctx, the map is shared and must not be modified.
 func getFields(ctx context.Context) map[string]any {
-	if m, ok := ctx.Value(fieldKey).(map[string]any); ok && m != nil {
-		return m
-	}
-	return nil
+	return getFieldsNode(ctx).All()
 }
 
-func getTags(ctx context.Context) map[string]string {
-	if m, ok := ctx.Value(tagKey).(map[string]string); ok && m != nil {
-		return m
-	}
-	return nil
+// rangeTags calls f for the tags of obsctx read by the logger: the tags added by the logger
+// and the allow-listed tags of the other sources (see WithSharedTags).
+func (o *options) rangeTags(ctx context.Context, f func(k string, v string)) {
+	obsctx.RangeSources(ctx, func(k string, v string, src obsctx.Source) bool {
+		if src&obsctx.Logger != 0 || o.sharedTags == nil {
+			f(k, v)
+		} else if _, ok := o.sharedTags[k]; ok {
+			f(k, v)
+		}
+		return true
+	})
 }
 
 func getError(ctx context.Context) error {
@@ -43,64 +55,33 @@ func getRequest(ctx context.Context) *http.Request {
 	return nil
 }
 
+func getLevel(ctx context.Context) (Level, bool) {
+	l, ok := ctx.Value(levelKey).(Level)
+	return l, ok
+}
+
+func addLevelToCtx(ctx context.Context, l Level) context.Context {
+	return context.WithValue(ctx, levelKey, l)
+}
+
 func addRequestToCtx(ctx context.Context, r *http.Request) context.Context {
 	return context.WithValue(ctx, requestKey, r)
 }
 
 func addFieldToCtx(ctx context.Context, k string, v any) context.Context {
-	m := getFields(ctx)
-	if len(m) == 0 {
-		return context.WithValue(ctx, fieldKey, map[string]any{
-			k: v,
-		})
-	}
-
-	nm := make(map[string]any, len(m)+1)
-	maps.Copy(nm, m)
-	nm[k] = v
-	return context.WithValue(ctx, fieldKey, nm)
+	return context.WithValue(ctx, fieldKey, getFieldsNode(ctx).With(k, v))
 }
 
 func addFieldsToCtx(ctx context.Context, fs map[string]any) context.Context {
-	m := getFields(ctx)
-	if len(m) == 0 {
-		nm := make(map[string]any, len(fs))
-		maps.Copy(nm, fs)
-		return context.WithValue(ctx, fieldKey, nm)
-	}
-
-	nm := make(map[string]any, len(m)+len(fs))
-	maps.Copy(nm, m)
-	maps.Copy(nm, fs)
-	return context.WithValue(ctx, fieldKey, nm)
+	return context.WithValue(ctx, fieldKey, getFieldsNode(ctx).WithAll(fs))
 }
 
 func addTagToCtx(ctx context.Context, k string, v string) context.Context {
-	m := getTags(ctx)
-	if len(m) == 0 {
-		return context.WithValue(ctx, tagKey, map[string]string{
-			k: v,
-		})
-	}
-
-	nm := make(map[string]string, len(m)+1)
-	maps.Copy(nm, m)
-	nm[k] = v
-	return context.WithValue(ctx, tagKey, nm)
+	return obsctx.WithSourceTag(ctx, obsctx.Logger, k, v)
 }
 
 func addTagsToCtx(ctx context.Context, tgs map[string]string) context.Context {
-	m := getTags(ctx)
-	if len(m) == 0 {
-		nm := make(map[string]string, len(tgs))
-		maps.Copy(nm, tgs)
-		return context.WithValue(ctx, tagKey, nm)
-	}
-
-	nm := make(map[string]string, len(m)+len(tgs))
-	maps.Copy(nm, m)
-	maps.Copy(nm, tgs)
-	return context.WithValue(ctx, tagKey, nm)
+	return obsctx.WithSourceTags(ctx, obsctx.Logger, tgs)
 }
 
 func addErrorToCtx(ctx context.Context, e error) context.Context {
@@ -118,41 +99,55 @@ func getCtxFields(ctx context.Context) map[string]any {
 	return nm
 }
 
-func getCtxTags(ctx context.Context) map[string]string {
-	m := getTags(ctx)
-	if m == nil {
-		return nil
-	}
-	nm := make(map[string]string, len(m))
-	maps.Copy(nm, m)
-
+// getCtxTags returns a copy of the tags read by the logger with the options o, nil if there are none.
+func getCtxTags(ctx context.Context, o *options) map[string]string {
+	var nm map[string]string
+	o.rangeTags(ctx, func(k string, v string) {
+		if nm == nil {
+			nm = map[string]string{}
+		}
+		nm[k] = v
+	})
 	return nm
 }
 
+// ContextFields returns a copy of the fields stored in ctx by Logger.WithField and similar methods.
+// It is intended for the code which builds events without the Logger, e.g. bridges from other loggers.
+func ContextFields(ctx context.Context) map[string]any {
+	return getCtxFields(defaultCtx(ctx))
+}
+
+// ContextTags returns a copy of the tags of ctx added by Logger.WithTag, Metrics.WithTag or obsctx, see ContextFields.
+// Unlike Logger.Tags the tags are not filtered by WithSharedTags.
+func ContextTags(ctx context.Context) map[string]string {
+	return obsctx.Tags(defaultCtx(ctx))
+}
+
+// ContextError returns the error stored in ctx by Logger.WithError.
+func ContextError(ctx context.Context) error {
+	return getError(defaultCtx(ctx))
+}
+
+// ContextRequest returns the request stored in ctx by Logger.WithRequest.
+func ContextRequest(ctx context.Context) *http.Request {
+	return getRequest(defaultCtx(ctx))
+}
+
+// copyCtx adds the data of src to dst, the values of src win.
 func copyCtx(dst context.Context, src context.Context) context.Context {
 	if srcm := getFields(src); srcm != nil {
-		dstm := getFields(dst)
-		if dstm == nil {
-			dstm = srcm
-		} else {
-			maps.Insert(dstm, maps.All(srcm))
-		}
-		dst = context.WithValue(dst, fieldKey, dstm)
+		dst = addFieldsToCtx(dst, srcm)
 	}
 
-	if srcm := getTags(src); srcm != nil {
-		dstm := getTags(dst)
-		if dstm == nil {
-			dstm = srcm
-		} else {
-			maps.Insert(dstm, maps.All(srcm))
-		}
-		dst = context.WithValue(dst, tagKey, dstm)
+	dst = obsctx.Copy(dst, src)
+
+	if l, ok := getLevel(src); ok {
+		dst = addLevelToCtx(dst, l)
 	}
 
 	err := getError(src)
 	if err != nil {
-		dst = addErrorToCtx(src, err)
+		dst = addErrorToCtx(dst, err)
 	}
 	return dst
 }
@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"strconv"
+	"testing"
+)
+
+func TestContextFields(t *testing.T) {
+	l := New(&recordDriver{})
+
+	parent := l.WithField(context.Background(), "a", 1)
+	parent = l.WithFields(parent, map[string]any{"b": 2, "c": 3})
+	child := l.WithField(parent, "a", 10)
+	sibling := l.WithField(parent, "d", 4)
+
+	if f := l.Fields(child); !maps.Equal(f, map[string]any{"a": 10, "b": 2, "c": 3}) {
+		t.Errorf("Unexpected child fields: %v", f)
+	}
+	if f := l.Fields(sibling); !maps.Equal(f, map[string]any{"a": 1, "b": 2, "c": 3, "d": 4}) {
+		t.Errorf("Unexpected sibling fields: %v", f)
+	}
+
+	f := l.Fields(parent)
+	f["a"] = "modified"
+	if f := l.Fields(parent); f["a"] != 1 {
+		t.Error("Expected Fields to return a copy")
+	}
+	if l.Fields(context.Background()) != nil || l.Tags(context.Background()) != nil {
+		t.Error("Expected no fields and tags")
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	l := New(&recordDriver{})
+
+	dst := l.WithTags(context.Background(), map[string]string{"a": "dst", "b": "dst"})
+	src := l.WithTag(context.Background(), "a", "src")
+	err := errors.New("src")
+	src = l.WithError(src, err)
+
+	res := l.WithContext(dst, src)
+	if tags := l.Tags(res); !maps.Equal(tags, map[string]string{"a": "src", "b": "dst"}) {
+		t.Errorf("Unexpected tags: %v", tags)
+	}
+	if tags := l.Tags(dst); !maps.Equal(tags, map[string]string{"a": "dst", "b": "dst"}) {
+		t.Errorf("Expected dst not to be modified, got %v", tags)
+	}
+	if getError(res) != err || l.Tags(res)["b"] != "dst" {
+		t.Error("Expected the error of src to be added to dst")
+	}
+}
+
+// addFieldByCopy is the previous storage which copied the whole map on every field, it is kept for the benchmarks.
+func addFieldByCopy(ctx context.Context, k string, v any) context.Context {
+	m, _ := ctx.Value(fieldKey).(map[string]any)
+	nm := make(map[string]any, len(m)+1)
+	maps.Copy(nm, m)
+	nm[k] = v
+	return context.WithValue(ctx, fieldKey, nm)
+}
+
+func benchmarkFields(b *testing.B, depth int, add func(ctx context.Context, k string, v any) context.Context, read func(ctx context.Context)) {
+	keys := make([]string, depth)
+	for i := range keys {
+		keys[i] = "field" + strconv.Itoa(i)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ctx := context.Background()
+		for j, k := range keys {
+			ctx = add(ctx, k, j)
+			read(ctx)
+		}
+	}
+}
+
+func BenchmarkContextFields(b *testing.B) {
+	var sink int
+	for _, depth := range []int{10, 100} {
+		b.Run("linked/"+strconv.Itoa(depth), func(b *testing.B) {
+			benchmarkFields(b, depth, addFieldToCtx, func(ctx context.Context) {
+				sink += len(getFields(ctx))
+			})
+		})
+		b.Run("copy/"+strconv.Itoa(depth), func(b *testing.B) {
+			benchmarkFields(b, depth, addFieldByCopy, func(ctx context.Context) {
+				m, _ := ctx.Value(fieldKey).(map[string]any)
+				sink += len(m)
+			})
+		})
+		// fields are added layer by layer and read only by the log line at the deepest layer
+		b.Run("linked-emit-once/"+strconv.Itoa(depth), func(b *testing.B) {
+			benchmarkFields(b, depth, addFieldToCtx, func(ctx context.Context) {})
+		})
+		b.Run("copy-emit-once/"+strconv.Itoa(depth), func(b *testing.B) {
+			benchmarkFields(b, depth, addFieldByCopy, func(ctx context.Context) {})
+		})
+	}
+	_ = sink
+}
+
+func BenchmarkLogWithFields(b *testing.B) {
+	l := New(&recordDriver{})
+	ctx := context.Background()
+	for i := 0; i < 20; i++ {
+		ctx = l.WithField(ctx, "field"+strconv.Itoa(i), i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Debug(ctx, "msg")
+	}
+}
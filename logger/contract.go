@@ -27,6 +27,16 @@ type Logger interface {
 	Warning(ctx context.Context, msg string, args ...any)
 	Error(ctx context.Context, msg string, args ...any)
 	Fatal(ctx context.Context, msg string, args ...any)
+	// Tracef and the others format the message only if the event passes the level.
+	Tracef(ctx context.Context, format string, args ...any)
+	Debugf(ctx context.Context, format string, args ...any)
+	Infof(ctx context.Context, format string, args ...any)
+	Warningf(ctx context.Context, format string, args ...any)
+	Errorf(ctx context.Context, format string, args ...any)
+	Fatalf(ctx context.Context, format string, args ...any)
+	Log(ctx context.Context, level Level, msg string, args ...any)
+	// LogAttrs is the variant of Log which doesn't box the args into any.
+	LogAttrs(ctx context.Context, level Level, msg string, attrs ...Attr)
 	Recover(ctx context.Context)
 	WithField(ctx context.Context, k string, v any) context.Context
 	WithFields(ctx context.Context, fields map[string]any) context.Context
@@ -37,18 +47,34 @@ type Logger interface {
 	Tags(ctx context.Context) map[string]string
 	WithContext(ctx context.Context, src context.Context) context.Context
 	WithRequest(ctx context.Context, request *http.Request) context.Context
+	// WithLevel lowers the minimum level for the events of ctx, a level above the logger level has no effect.
+	WithLevel(ctx context.Context, level Level) context.Context
 	WrapError(ctx context.Context, err error) error
 	Field(k string, v any) any
 	Err(err error) any
 	Tag(k string, v string) any
 	Flush(timeout time.Duration) bool
+	// With returns the child logger which adds args (fields, tags, errors) to each event.
+	// The child shares the driver with the parent.
+	With(args ...any) Logger
+	// Named returns the child logger with the name appended to the dotted name of the parent,
+	// the name is written to the LoggerNameKey field.
+	Named(name string) Logger
 }
 
 type EventHandler interface {
+	Level() Level
+	// Time returns the moment of the logger call.
+	Time() time.Time
 	Msg() string
+	// Fields yields the fields including the values of the attrs.
 	Fields() iter.Seq2[string, any]
+	// Attrs yields the same data as Fields, the typed attrs keep their kind, other fields are of KindAny.
+	Attrs() iter.Seq[Attr]
 	Tags() iter.Seq2[string, string]
 	Args() iter.Seq2[int, any]
 	Err() error
 	Req() *http.Request
+	// Caller returns the location of the logger call, nil if the capturing is disabled (see WithCaller).
+	Caller() *Caller
 }
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"net/http"
+	"strconv"
+)
+
+const DefaultDebugHeader = "X-Debug-Log"
+
+type debugOptions struct {
+	header  string
+	level   Level
+	trusted func(r *http.Request) bool
+}
+
+type DebugOption func(o *debugOptions)
+
+func newDebugOptions() *debugOptions {
+	return &debugOptions{
+		header:  DefaultDebugHeader,
+		level:   TraceLevel,
+		trusted: nil,
+	}
+}
+
+func WithDebugHeader(name string) DebugOption {
+	return func(o *debugOptions) {
+		o.header = name
+	}
+}
+
+func WithDebugLevel(level Level) DebugOption {
+	return func(o *debugOptions) {
+		o.level = level
+	}
+}
+
+// WithDebugTrust sets the check which decides whether the debug header of the request can be trusted.
+// Without it the header is trusted as is, so it must be stripped from external traffic by the ingress.
+func WithDebugTrust(f func(r *http.Request) bool) DebugOption {
+	return func(o *debugOptions) {
+		o.trusted = f
+	}
+}
+
+// NewDebugMiddleware lowers the log level for a single request when it carries the debug header
+// (X-Debug-Log: 1 by default), the rest of the traffic is logged with the logger level.
+func NewDebugMiddleware(l Logger, opts ...DebugOption) func(next http.Handler) http.Handler {
+	o := newDebugOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if enabled, err := strconv.ParseBool(r.Header.Get(o.header)); err == nil && enabled {
+				if o.trusted == nil || o.trusted(r) {
+					r = r.WithContext(l.WithLevel(r.Context(), o.level))
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordDriver struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+func (d *recordDriver) write(h EventHandler) {
+	d.mu.Lock()
+	d.msgs = append(d.msgs, h.Msg())
+	d.mu.Unlock()
+}
+
+func (d *recordDriver) Trace(ctx context.Context, h EventHandler)   { d.write(h) }
+func (d *recordDriver) Debug(ctx context.Context, h EventHandler)   { d.write(h) }
+func (d *recordDriver) Warning(ctx context.Context, h EventHandler) { d.write(h) }
+func (d *recordDriver) Info(ctx context.Context, h EventHandler)    { d.write(h) }
+func (d *recordDriver) Error(ctx context.Context, h EventHandler)   { d.write(h) }
+func (d *recordDriver) Fatal(ctx context.Context, h EventHandler)   { d.write(h) }
+func (d *recordDriver) Flush(timeout time.Duration) error           { return nil }
+func (d *recordDriver) Recover(err any, ctx context.Context, h EventHandler) {
+	d.write(h)
+}
+
+func TestWithLevel(t *testing.T) {
+	d := &recordDriver{}
+	l := New(d, WithMinLevel(InfoLevel))
+
+	ctx := context.Background()
+	l.Debug(ctx, "skipped")
+	l.Debug(l.WithLevel(ctx, DebugLevel), "debug")
+	l.Trace(l.WithLevel(ctx, DebugLevel), "skipped")
+	l.Info(ctx, "info")
+
+	if len(d.msgs) != 2 || d.msgs[0] != "debug" || d.msgs[1] != "info" {
+		t.Errorf("Unexpected messages: %v", d.msgs)
+	}
+}
+
+func TestWithLevelDoesNotRaise(t *testing.T) {
+	d := &recordDriver{}
+	level := NewAtomicLevel(DebugLevel)
+	l := New(d, WithAtomicLevel(level))
+
+	ctx := l.WithLevel(context.Background(), ErrorLevel)
+	l.Warning(ctx, "warning")
+
+	// the global level still applies to the contexts with a level
+	level.SetLevel(ErrorLevel)
+	l.Warning(ctx, "skipped")
+	l.Debug(l.WithLevel(context.Background(), DebugLevel), "debug")
+
+	if len(d.msgs) != 2 || d.msgs[0] != "warning" || d.msgs[1] != "debug" {
+		t.Errorf("Unexpected messages: %v", d.msgs)
+	}
+}
+
+func TestDebugMiddleware(t *testing.T) {
+	d := &recordDriver{}
+	l := New(d, WithMinLevel(InfoLevel))
+
+	handler := NewDebugMiddleware(l, WithDebugTrust(func(r *http.Request) bool {
+		return r.RemoteAddr == "10.0.0.1:1234"
+	}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.Trace(r.Context(), r.URL.Path)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/trusted", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set(DefaultDebugHeader, "1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	req = httptest.NewRequest(http.MethodGet, "/untrusted", nil)
+	req.Header.Set(DefaultDebugHeader, "1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/plain", nil))
+
+	if len(d.msgs) != 1 || d.msgs[0] != "/trusted" {
+		t.Errorf("Unexpected messages: %v", d.msgs)
+	}
+}
@@ -0,0 +1,168 @@
+package dedup
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+type entry struct {
+	ctx      context.Context
+	level    logger.Level
+	event    *logger.Event
+	first    time.Time
+	last     time.Time
+	repeated int
+	timer    *time.Timer
+}
+
+type driver struct {
+	d logger.Driver
+	o *options
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+// NewDedupDriver suppresses events identical to one written during the window. Events are identical when
+// they have the same level, message, error and tags. When the window is over a single summary event
+// with the repeated count and the first_seen/last_seen timestamps is written for the suppressed ones.
+// Fatal and Recover are never suppressed.
+func NewDedupDriver(d logger.Driver, opts ...Option) logger.Driver {
+	o := newOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &driver{
+		d:       d,
+		o:       o,
+		entries: map[string]*entry{},
+	}
+}
+
+func eventKey(level logger.Level, h logger.EventHandler) string {
+	var b strings.Builder
+	b.WriteString(level.String())
+	b.WriteByte(0)
+	b.WriteString(h.Msg())
+	b.WriteByte(0)
+	if err := h.Err(); err != nil {
+		b.WriteString(err.Error())
+	}
+
+	tags := maps.Collect(h.Tags())
+	for _, k := range slices.Sorted(maps.Keys(tags)) {
+		b.WriteByte(0)
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(tags[k])
+	}
+	return b.String()
+}
+
+func (d *driver) write(ctx context.Context, level logger.Level, h logger.EventHandler) {
+	k := eventKey(level, h)
+	now := time.Now()
+
+	d.mu.Lock()
+	if e, ok := d.entries[k]; ok {
+		if e.event == nil {
+			e.event = logger.Snapshot(h)
+		}
+		e.repeated++
+		e.last = now
+		d.mu.Unlock()
+		return
+	}
+
+	e := &entry{
+		ctx:   context.WithoutCancel(ctx),
+		level: level,
+		first: now,
+		last:  now,
+	}
+	e.timer = time.AfterFunc(d.o.window, func() {
+		d.expire(k, e)
+	})
+	d.entries[k] = e
+	d.mu.Unlock()
+
+	logger.Dispatch(ctx, d.d, level, h)
+}
+
+func (d *driver) expire(k string, e *entry) {
+	d.mu.Lock()
+	if d.entries[k] != e {
+		d.mu.Unlock()
+		return
+	}
+	delete(d.entries, k)
+	d.mu.Unlock()
+
+	d.summary(e)
+}
+
+func (d *driver) summary(e *entry) {
+	if e.repeated == 0 {
+		return
+	}
+	e.event.
+		SetField("repeated", e.repeated).
+		SetField("first_seen", e.first).
+		SetField("last_seen", e.last)
+	logger.Dispatch(e.ctx, d.d, e.level, e.event)
+}
+
+func (d *driver) Trace(ctx context.Context, h logger.EventHandler) {
+	d.write(ctx, logger.TraceLevel, h)
+}
+
+func (d *driver) Debug(ctx context.Context, h logger.EventHandler) {
+	d.write(ctx, logger.DebugLevel, h)
+}
+
+func (d *driver) Info(ctx context.Context, h logger.EventHandler) {
+	d.write(ctx, logger.InfoLevel, h)
+}
+
+func (d *driver) Warning(ctx context.Context, h logger.EventHandler) {
+	d.write(ctx, logger.WarningLevel, h)
+}
+
+func (d *driver) Error(ctx context.Context, h logger.EventHandler) {
+	d.write(ctx, logger.ErrorLevel, h)
+}
+
+func (d *driver) Fatal(ctx context.Context, h logger.EventHandler) {
+	d.flushSummaries()
+	d.d.Fatal(ctx, h)
+}
+
+func (d *driver) Recover(err any, ctx context.Context, h logger.EventHandler) {
+	d.d.Recover(err, ctx, h)
+}
+
+// Flush writes the summaries of all suppressed events without waiting for their windows and flushes
+// the wrapped driver.
+func (d *driver) Flush(timeout time.Duration) error {
+	d.flushSummaries()
+	return d.d.Flush(timeout)
+}
+
+func (d *driver) flushSummaries() {
+	d.mu.Lock()
+	entries := d.entries
+	d.entries = map[string]*entry{}
+	d.mu.Unlock()
+
+	for _, e := range entries {
+		e.timer.Stop()
+		d.summary(e)
+	}
+}
@@ -0,0 +1,175 @@
+package dedup
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+type record struct {
+	level  logger.Level
+	msg    string
+	fields map[string]any
+}
+
+type recordDriver struct {
+	mu      sync.Mutex
+	records []record
+}
+
+func (d *recordDriver) add(level logger.Level, h logger.EventHandler) {
+	r := record{level: level, msg: h.Msg(), fields: map[string]any{}}
+	for k, v := range h.Fields() {
+		r.fields[k] = v
+	}
+	d.mu.Lock()
+	d.records = append(d.records, r)
+	d.mu.Unlock()
+}
+
+func (d *recordDriver) get() []record {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]record(nil), d.records...)
+}
+
+func (d *recordDriver) Trace(ctx context.Context, h logger.EventHandler) {
+	d.add(logger.TraceLevel, h)
+}
+
+func (d *recordDriver) Debug(ctx context.Context, h logger.EventHandler) {
+	d.add(logger.DebugLevel, h)
+}
+
+func (d *recordDriver) Warning(ctx context.Context, h logger.EventHandler) {
+	d.add(logger.WarningLevel, h)
+}
+
+func (d *recordDriver) Info(ctx context.Context, h logger.EventHandler) {
+	d.add(logger.InfoLevel, h)
+}
+
+func (d *recordDriver) Error(ctx context.Context, h logger.EventHandler) {
+	d.add(logger.ErrorLevel, h)
+}
+
+func (d *recordDriver) Fatal(ctx context.Context, h logger.EventHandler) {
+	d.add(logger.FatalLevel, h)
+}
+
+func (d *recordDriver) Flush(timeout time.Duration) error {
+	return nil
+}
+
+func (d *recordDriver) Recover(err any, ctx context.Context, h logger.EventHandler) {
+	d.add(logger.ErrorLevel, h)
+}
+
+func checkSummary(t *testing.T, r record, msg string, repeated int) {
+	t.Helper()
+
+	if r.msg != msg || r.fields["repeated"] != repeated {
+		t.Errorf("Expected summary of %q repeated %d times, got %q %v", msg, repeated, r.msg, r.fields)
+	}
+	first, _ := r.fields["first_seen"].(time.Time)
+	last, _ := r.fields["last_seen"].(time.Time)
+	if first.IsZero() || last.Before(first) {
+		t.Errorf("Expected first_seen <= last_seen, got %v and %v", first, last)
+	}
+}
+
+func TestSuppress(t *testing.T) {
+	d := &recordDriver{}
+	l := logger.New(NewDedupDriver(d, WithWindow(time.Hour)))
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		l.Warning(ctx, "disk is full")
+	}
+	l.Warning(ctx, "disk is full", l.Tag("disk", "sdb"))
+	l.Info(ctx, "disk is full")
+
+	if records := d.get(); len(records) != 3 {
+		t.Errorf("Expected 3 events, got %v", records)
+	}
+}
+
+func TestSummaryAfterWindow(t *testing.T) {
+	d := &recordDriver{}
+	l := logger.New(NewDedupDriver(d, WithWindow(20*time.Millisecond)))
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		l.Error(ctx, "connection refused")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(d.get()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	records := d.get()
+	if len(records) != 2 {
+		t.Fatalf("Expected the event and its summary, got %v", records)
+	}
+	checkSummary(t, records[1], "connection refused", 2)
+
+	// the window is over, so the next event is written again
+	l.Error(ctx, "connection refused")
+	if records := d.get(); len(records) != 3 {
+		t.Errorf("Expected the event after the window to be written, got %v", records)
+	}
+}
+
+func TestFlush(t *testing.T) {
+	d := &recordDriver{}
+	l := logger.New(NewDedupDriver(d, WithWindow(time.Hour)))
+
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		l.Info(ctx, "cache miss")
+	}
+	l.Info(ctx, "cache hit")
+	l.Flush(time.Second)
+
+	records := d.get()
+	if len(records) != 3 {
+		t.Fatalf("Expected 2 events and 1 summary, got %v", records)
+	}
+	checkSummary(t, records[2], "cache miss", 3)
+
+	l.Flush(time.Second)
+	if records := d.get(); len(records) != 3 {
+		t.Errorf("Expected the summary to be written once, got %v", records)
+	}
+}
+
+func TestErrorAndFatal(t *testing.T) {
+	d := &recordDriver{}
+	l := logger.New(NewDedupDriver(d, WithWindow(time.Hour)), logger.WithExitFunc(func(code int) {}))
+
+	ctx := context.Background()
+	l.Error(ctx, "boom")
+	l.Error(ctx, "boom")
+	l.Fatal(ctx, "boom")
+	l.Fatal(ctx, "boom")
+	func() {
+		defer l.Recover(ctx)
+		panic("boom")
+	}()
+
+	records := d.get()
+	if len(records) != 5 {
+		t.Fatalf("Expected error, its summary, 2 fatal events and the panic, got %v", records)
+	}
+	if records[0].level != logger.ErrorLevel {
+		t.Errorf("Expected the first error to be written, got %v", records[0])
+	}
+	// the pending summaries are written before the process exits
+	checkSummary(t, records[1], "boom", 1)
+	if records[2].level != logger.FatalLevel || records[3].level != logger.FatalLevel {
+		t.Errorf("Expected fatal events not to be suppressed, got %v", records)
+	}
+}
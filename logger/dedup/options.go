@@ -0,0 +1,22 @@
+package dedup
+
+import "time"
+
+type options struct {
+	window time.Duration
+}
+
+type Option func(o *options)
+
+func newOptions() *options {
+	return &options{
+		window: 10 * time.Second,
+	}
+}
+
+// WithWindow sets the period since the first event during which identical events are suppressed.
+func WithWindow(d time.Duration) Option {
+	return func(o *options) {
+		o.window = d
+	}
+}
@@ -0,0 +1,21 @@
+package logger
+
+import "context"
+
+// Dispatch passes h to the method of d which corresponds to the level.
+func Dispatch(ctx context.Context, d Driver, level Level, h EventHandler) {
+	switch level {
+	case TraceLevel:
+		d.Trace(ctx, h)
+	case DebugLevel:
+		d.Debug(ctx, h)
+	case InfoLevel:
+		d.Info(ctx, h)
+	case WarningLevel:
+		d.Warning(ctx, h)
+	case ErrorLevel:
+		d.Error(ctx, h)
+	case FatalLevel:
+		d.Fatal(ctx, h)
+	}
+}
@@ -0,0 +1,387 @@
+// Package drivertest checks that a logger.Driver follows the contract of the logger, the checks are shared
+// by the drivers of this module and can be used for any other driver.
+package drivertest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+const (
+	reusedValue = "drivertest reused"
+	panicValue  = "drivertest panic"
+)
+
+// Entry is an event as it was written by the driver, the inspector of the harness converts the output of the driver
+// into entries. Drivers which don't separate tags and fields may put everything into Fields.
+type Entry struct {
+	Level  logger.Level
+	Msg    string
+	Fields map[string]any
+	Tags   map[string]string
+	// Args are the positional args, drivers which write the key-value args as fields may leave it empty
+	Args []any
+	// Err is the message of the written error
+	Err string
+	// Request is any representation of the written request which contains its URL, e.g. the curl command
+	Request string
+	// Panic is the written value of the recovered panic, if the driver writes it separately from the message
+	Panic string
+}
+
+// Value returns the value of the tag or field k.
+func (e Entry) Value(k string) (any, bool) {
+	if v, ok := e.Tags[k]; ok {
+		return v, true
+	}
+	v, ok := e.Fields[k]
+	return v, ok
+}
+
+// Harness is the driver under the test and the inspector of its output.
+type Harness struct {
+	Driver logger.Driver
+	// Entries returns the events written by the driver, it is called after Driver.Flush.
+	Entries func() []Entry
+}
+
+// Factory creates a new driver for each check, the driver must call exit instead of os.Exit in Fatal.
+type Factory func(t *testing.T, exit func(code int)) Harness
+
+type options struct {
+	levels  []logger.Level
+	mapping func(logger.Level) logger.Level
+	exit    bool
+}
+
+type Option func(o *options)
+
+func newOptions() *options {
+	return &options{
+		levels: []logger.Level{
+			logger.TraceLevel,
+			logger.DebugLevel,
+			logger.InfoLevel,
+			logger.WarningLevel,
+			logger.ErrorLevel,
+			logger.FatalLevel,
+		},
+		mapping: func(l logger.Level) logger.Level {
+			return l
+		},
+		exit: true,
+	}
+}
+
+// WithLevels sets the levels which are written by the driver, e.g. an error tracker may skip the lower levels.
+// All levels are written by default.
+func WithLevels(levels ...logger.Level) Option {
+	return func(o *options) {
+		o.levels = levels
+	}
+}
+
+// WithLevelMapping sets the level of the entry written for the event of each level,
+// e.g. a driver may write Trace as Debug if the underlying logger has no Trace level.
+func WithLevelMapping(f func(logger.Level) logger.Level) Option {
+	return func(o *options) {
+		o.mapping = f
+	}
+}
+
+// WithoutExit is for the drivers which leave the exit to logger.Logger, Fatal of such a driver must not exit.
+func WithoutExit() Option {
+	return func(o *options) {
+		o.exit = false
+	}
+}
+
+func (o *options) enabled(l logger.Level) bool {
+	return slices.Contains(o.levels, l)
+}
+
+// level returns the level used by the checks of the data, the error level is preferred as it is written by most drivers.
+func (o *options) level() logger.Level {
+	if o.enabled(logger.ErrorLevel) {
+		return logger.ErrorLevel
+	}
+	for _, l := range slices.Backward(o.levels) {
+		if l != logger.FatalLevel {
+			return l
+		}
+	}
+	return logger.ErrorLevel
+}
+
+type exitRecorder struct {
+	mu    sync.Mutex
+	codes []int
+}
+
+func (r *exitRecorder) exit(code int) {
+	r.mu.Lock()
+	r.codes = append(r.codes, code)
+	r.mu.Unlock()
+}
+
+func (r *exitRecorder) get() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.codes)
+}
+
+// Run runs all checks against the drivers created by f.
+func Run(t *testing.T, f Factory, opts ...Option) {
+	o := newOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	checks := []struct {
+		name  string
+		check func(t *testing.T, f Factory, o *options)
+	}{
+		{"Levels", checkLevels},
+		{"Data", checkData},
+		{"PooledData", checkPooledData},
+		{"Concurrent", checkConcurrent},
+		{"Flush", checkFlush},
+		{"Recover", checkRecover},
+		{"Fatal", checkFatal},
+	}
+	for _, c := range checks {
+		t.Run(c.name, func(t *testing.T) {
+			c.check(t, f, o)
+		})
+	}
+}
+
+func newHarness(t *testing.T, f Factory) (Harness, *exitRecorder) {
+	r := &exitRecorder{}
+	return f(t, r.exit), r
+}
+
+func entries(t *testing.T, h Harness) []Entry {
+	if err := h.Driver.Flush(time.Second); err != nil {
+		t.Errorf("Flush: unexpected error %v", err)
+	}
+	return h.Entries()
+}
+
+func call(ctx context.Context, d logger.Driver, h *handler) {
+	switch h.level {
+	case logger.TraceLevel:
+		d.Trace(ctx, h)
+	case logger.DebugLevel:
+		d.Debug(ctx, h)
+	case logger.InfoLevel:
+		d.Info(ctx, h)
+	case logger.WarningLevel:
+		d.Warning(ctx, h)
+	case logger.ErrorLevel:
+		d.Error(ctx, h)
+	case logger.FatalLevel:
+		d.Fatal(ctx, h)
+	}
+}
+
+type written struct {
+	level logger.Level
+	msg   string
+}
+
+func checkLevels(t *testing.T, f Factory, o *options) {
+	h, exits := newHarness(t, f)
+	ctx := context.Background()
+
+	var expected []written
+	for _, level := range []logger.Level{logger.TraceLevel, logger.DebugLevel, logger.InfoLevel, logger.WarningLevel, logger.ErrorLevel} {
+		method := newHandler(level, "drivertest method "+level.String())
+		call(ctx, h.Driver, method)
+
+		if o.enabled(level) {
+			expected = append(expected, written{o.mapping(level), method.msg})
+		}
+	}
+
+	var got []written
+	for _, e := range entries(t, h) {
+		got = append(got, written{e.Level, e.Msg})
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("Expected entries %v, got %v", expected, got)
+	}
+	if codes := exits.get(); len(codes) != 0 {
+		t.Errorf("Expected no exit, got %v", codes)
+	}
+}
+
+func newDataHandler(level logger.Level) *handler {
+	h := newHandler(level, "drivertest data")
+	h.fields["drivertest_field"] = "field value"
+	h.attrs = append(h.attrs, logger.Int("drivertest_attr", 42))
+	h.tags["drivertest_tag"] = "tag value"
+	h.args = append(h.args, "drivertest_arg", "arg value")
+	h.err = errors.New("drivertest error")
+	h.req, _ = http.NewRequest(http.MethodGet, "http://example.com/drivertest", nil)
+	return h
+}
+
+func checkEntryData(t *testing.T, e Entry) {
+	t.Helper()
+
+	values := map[string]string{
+		"drivertest_field": "field value",
+		"drivertest_attr":  "42",
+		"drivertest_tag":   "tag value",
+	}
+	for k, expected := range values {
+		if v, ok := e.Value(k); !ok || fmt.Sprint(v) != expected {
+			t.Errorf("Expected %s=%s, got %v", k, expected, v)
+		}
+	}
+
+	argWritten := slices.ContainsFunc(e.Args, func(arg any) bool {
+		return fmt.Sprint(arg) == "arg value"
+	})
+	if v, ok := e.Value("drivertest_arg"); ok && fmt.Sprint(v) == "arg value" {
+		argWritten = true
+	}
+	if !argWritten {
+		t.Errorf("Expected arg to be written, got args %v and fields %v", e.Args, e.Fields)
+	}
+
+	if !strings.Contains(e.Err, "drivertest error") {
+		t.Errorf("Expected error to be written, got %q", e.Err)
+	}
+	if !strings.Contains(e.Request, "example.com/drivertest") {
+		t.Errorf("Expected request to be written, got %q", e.Request)
+	}
+}
+
+func checkData(t *testing.T, f Factory, o *options) {
+	h, _ := newHarness(t, f)
+
+	call(context.Background(), h.Driver, newDataHandler(o.level()))
+
+	es := entries(t, h)
+	if len(es) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(es))
+	}
+	if es[0].Msg != "drivertest data" {
+		t.Errorf("Expected message %q, got %q", "drivertest data", es[0].Msg)
+	}
+	checkEntryData(t, es[0])
+}
+
+// checkPooledData checks that the driver doesn't keep the data of the handler after the call,
+// the logger returns it to the pool and reuses for the next event.
+func checkPooledData(t *testing.T, f Factory, o *options) {
+	h, _ := newHarness(t, f)
+
+	handler := newDataHandler(o.level())
+	call(context.Background(), h.Driver, handler)
+	handler.reuse()
+
+	es := entries(t, h)
+	if len(es) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(es))
+	}
+	if es[0].Msg != "drivertest data" {
+		t.Errorf("Expected message %q, got %q", "drivertest data", es[0].Msg)
+	}
+	checkEntryData(t, es[0])
+}
+
+func checkConcurrent(t *testing.T, f Factory, o *options) {
+	const (
+		goroutines = 8
+		events     = 25
+	)
+	h, _ := newHarness(t, f)
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < events; j++ {
+				handler := newDataHandler(o.level())
+				handler.msg = "drivertest concurrent"
+				call(context.Background(), h.Driver, handler)
+			}
+		}()
+	}
+	wg.Wait()
+
+	n := 0
+	for _, e := range entries(t, h) {
+		if e.Msg == "drivertest concurrent" {
+			n++
+		}
+	}
+	if n != goroutines*events {
+		t.Errorf("Expected %d entries, got %d", goroutines*events, n)
+	}
+}
+
+func checkFlush(t *testing.T, f Factory, o *options) {
+	h, _ := newHarness(t, f)
+
+	if err := h.Driver.Flush(time.Second); err != nil {
+		t.Errorf("Expected no error on empty flush, got %v", err)
+	}
+	call(context.Background(), h.Driver, newHandler(o.level(), "drivertest flush"))
+	if es := entries(t, h); len(es) != 1 || es[0].Msg != "drivertest flush" {
+		t.Errorf("Expected the event to be written after Flush, got %v", es)
+	}
+}
+
+func checkRecover(t *testing.T, f Factory, o *options) {
+	h, exits := newHarness(t, f)
+
+	h.Driver.Recover(panicValue, context.Background(), newHandler(logger.ErrorLevel, ""))
+
+	found := false
+	for _, e := range entries(t, h) {
+		if strings.Contains(e.Msg, panicValue) || strings.Contains(e.Err, panicValue) || strings.Contains(e.Panic, panicValue) {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected the panic to be written")
+	}
+	if codes := exits.get(); len(codes) != 0 {
+		t.Errorf("Expected Recover not to exit, got %v", codes)
+	}
+}
+
+func checkFatal(t *testing.T, f Factory, o *options) {
+	h, exits := newHarness(t, f)
+
+	h.Driver.Fatal(context.Background(), newHandler(logger.FatalLevel, "drivertest fatal"))
+
+	codes := exits.get()
+	if o.exit && (len(codes) != 1 || codes[0] == 0) {
+		t.Errorf("Expected one exit with non-zero code, got %v", codes)
+	}
+	if !o.exit && len(codes) != 0 {
+		t.Errorf("Expected no exit, got %v", codes)
+	}
+	if !o.enabled(logger.FatalLevel) {
+		return
+	}
+	es := entries(t, h)
+	if len(es) != 1 || es[0].Msg != "drivertest fatal" || es[0].Level != o.mapping(logger.FatalLevel) {
+		t.Errorf("Expected the event to be written before exit, got %v", es)
+	}
+}
@@ -0,0 +1,114 @@
+package drivertest
+
+import (
+	"iter"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+// handler owns its data like the handler of the logger, so the checks can reuse it after the driver call
+// the same way the pooled handler is reused.
+type handler struct {
+	level  logger.Level
+	time   time.Time
+	msg    string
+	fields map[string]any
+	attrs  []logger.Attr
+	tags   map[string]string
+	args   []any
+	err    error
+	req    *http.Request
+}
+
+func newHandler(level logger.Level, msg string) *handler {
+	return &handler{
+		level:  level,
+		time:   time.Now(),
+		msg:    msg,
+		fields: map[string]any{},
+		tags:   map[string]string{},
+	}
+}
+
+// reuse overwrites the data of the handler as the pool would do with the next event.
+func (h *handler) reuse() {
+	for k := range h.fields {
+		h.fields[k] = reusedValue
+	}
+	for i := range h.attrs {
+		h.attrs[i] = logger.String(h.attrs[i].Key, reusedValue)
+	}
+	for k := range h.tags {
+		h.tags[k] = reusedValue
+	}
+	for i := range h.args {
+		h.args[i] = reusedValue
+	}
+	h.msg = reusedValue
+}
+
+func (h *handler) Level() logger.Level {
+	return h.level
+}
+
+func (h *handler) Time() time.Time {
+	return h.time
+}
+
+func (h *handler) Msg() string {
+	return h.msg
+}
+
+func (h *handler) Fields() iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		for k, v := range h.fields {
+			if !yield(k, v) {
+				return
+			}
+		}
+		for _, a := range h.attrs {
+			if !yield(a.Key, a.Value()) {
+				return
+			}
+		}
+	}
+}
+
+func (h *handler) Attrs() iter.Seq[logger.Attr] {
+	return func(yield func(logger.Attr) bool) {
+		for k, v := range h.fields {
+			if !yield(logger.Any(k, v)) {
+				return
+			}
+		}
+		for _, a := range h.attrs {
+			if !yield(a) {
+				return
+			}
+		}
+	}
+}
+
+func (h *handler) Tags() iter.Seq2[string, string] {
+	return maps.All(h.tags)
+}
+
+func (h *handler) Args() iter.Seq2[int, any] {
+	return slices.All(h.args)
+}
+
+func (h *handler) Err() error {
+	return h.err
+}
+
+func (h *handler) Req() *http.Request {
+	return h.req
+}
+
+func (h *handler) Caller() *logger.Caller {
+	return nil
+}
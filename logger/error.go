@@ -2,7 +2,9 @@ package logger
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"maps"
+	"runtime"
 )
 
 type errorWithFields interface {
@@ -16,6 +18,8 @@ type errorWithTags interface {
 type errWrapper struct {
 	fields map[string]any
 	tags   map[string]string
+	// stack is captured by the first wrap in the chain, see WithErrorStack
+	stack []uintptr
 
 	err error
 }
@@ -33,42 +37,112 @@ func (e *errWrapper) Cause() error {
 	return e.err
 }
 
-func withError(ctx context.Context, err error) context.Context {
-	if ctx == nil {
-		ctx = context.Background()
+// ErrorCause describes one error of the chain in the ErrorChainKey field.
+type ErrorCause struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+// ErrorChainKey is the field which holds the causes of the logged error, see WithErrorChain.
+const ErrorChainKey = "error_chain"
+
+// maxErrorDepth protects from the cyclic or too deep unwrap trees.
+const maxErrorDepth = 32
+
+// walkError visits err and the whole tree of the errors wrapped by it, including errors.Join branches.
+// The errors are visited depth-first, so an outer error is always visited before the errors it wraps.
+func walkError(err error, visit func(err error)) {
+	walkErrorDepth(err, visit, 0)
+}
+
+func walkErrorDepth(err error, visit func(err error), depth int) {
+	if err == nil || depth >= maxErrorDepth {
+		return
 	}
-	if err == nil {
-		return ctx
+	visit(err)
+
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		walkErrorDepth(e.Unwrap(), visit, depth+1)
+	case interface{ Unwrap() []error }:
+		for _, child := range e.Unwrap() {
+			walkErrorDepth(child, visit, depth+1)
+		}
+	case interface{ Cause() error }:
+		walkErrorDepth(e.Cause(), visit, depth+1)
 	}
+}
 
+// errorData collects fields and tags of all errors in the tree of err, the outer error wins on conflicts.
+func errorData(err error) (map[string]any, map[string]string) {
 	var (
-		errFields = map[string]interface{}{}
+		errFields = map[string]any{}
 		errTags   = map[string]string{}
 	)
+	walkError(err, func(e error) {
+		var (
+			fields map[string]any
+			tags   map[string]string
+		)
+		switch we := e.(type) {
+		case *errWrapper:
+			fields, tags = we.fields, we.tags
+		default:
+			if ef, ok := e.(errorWithFields); ok {
+				fields = ef.LoggerFields()
+			}
+			if et, ok := e.(errorWithTags); ok {
+				tags = et.LoggerTags()
+			}
+		}
 
-	var wrappedErr *errWrapper
-	if errors.As(err, &wrappedErr) {
-		// if we have errWrapper somewhere inside err, then we will extract its fields and tags
-		errFields = wrappedErr.fields
-		errTags = wrappedErr.tags
-	}
-
-	if wrappedErr, ok := err.(*errWrapper); ok {
-		// if this err is already err wrapper, then we will unwrap it to reduce stack.
-		// Fields and tags were extracted in the previous step
-		err = wrappedErr.err
-	}
+		for k, v := range fields {
+			if _, ok := errFields[k]; !ok {
+				errFields[k] = v
+			}
+		}
+		for k, v := range tags {
+			if _, ok := errTags[k]; !ok {
+				errTags[k] = v
+			}
+		}
+	})
+	return errFields, errTags
+}
 
-	if errWithFields, ok := err.(errorWithFields); ok {
-		for k, v := range errWithFields.LoggerFields() {
-			errFields[k] = v
+// errorChain describes the errors in the tree of err. The wrappers of this package and the errors.Join like
+// nodes are skipped, the message of the latter only repeats the messages of their children.
+func errorChain(err error) []ErrorCause {
+	var chain []ErrorCause
+	walkError(err, func(e error) {
+		if _, ok := e.(*errWrapper); ok {
+			return
 		}
+		if _, ok := e.(interface{ Unwrap() []error }); ok {
+			return
+		}
+		chain = append(chain, ErrorCause{
+			Message: e.Error(),
+			Type:    fmt.Sprintf("%T", e),
+		})
+	})
+	return chain
+}
+
+func withError(ctx context.Context, err error) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err == nil {
+		return ctx
 	}
 
-	if errWithTags, ok := err.(errorWithTags); ok {
-		for k, v := range errWithTags.LoggerTags() {
-			errTags[k] = v
-		}
+	errFields, errTags := errorData(err)
+
+	if wrappedErr, ok := err.(*errWrapper); ok && wrappedErr.stack == nil {
+		// if this err is already err wrapper, then we will unwrap it to reduce stack.
+		// Fields and tags were extracted in the previous step, the wrapper with the stack trace is kept
+		err = wrappedErr.err
 	}
 
 	ctx = addFieldsToCtx(ctx, errFields)
@@ -79,7 +153,7 @@ func withError(ctx context.Context, err error) context.Context {
 
 // wrapError оборачивает переданную ошибку err тегами и полями из ctx и возвращает новую ошибку,
 // которую затем можно использовать в методах withField и подобных для логирования ее вместе с данными из контекста
-func wrapError(ctx context.Context, err error) error {
+func wrapError(ctx context.Context, err error, ctxTags map[string]string, captureStack bool) error {
 	if err == nil {
 		return err // maintain error type
 	}
@@ -88,36 +162,59 @@ func wrapError(ctx context.Context, err error) error {
 		return err
 	}
 
-	var (
-		ctxFields = getFields(ctx)
-		ctxTags   = getTags(ctx)
-	)
+	ctxFields := maps.Clone(getFields(ctx))
+	if ctxFields == nil {
+		ctxFields = map[string]any{}
+	}
+	if ctxTags == nil {
+		ctxTags = map[string]string{}
+	}
 
-	var wrappedErr *errWrapper
-	if errors.As(err, &wrappedErr) {
-		// if we have errWrapper somewhere inside err, then we will extract its fields and tags
-		for name, value := range wrappedErr.fields {
-			if _, ok := ctxFields[name]; !ok {
-				ctxFields[name] = value
-			}
+	// if we have errWrapper or errors with fields somewhere inside err, then we will extract their fields and tags
+	errFields, errTags := errorData(err)
+	for name, value := range errFields {
+		if _, ok := ctxFields[name]; !ok {
+			ctxFields[name] = value
 		}
-
-		for name, value := range wrappedErr.tags {
-			if _, ok := ctxTags[name]; !ok {
-				ctxTags[name] = value
-			}
+	}
+	for name, value := range errTags {
+		if _, ok := ctxTags[name]; !ok {
+			ctxTags[name] = value
 		}
 	}
 
+	stack := errorStack(err)
 	if wrappedErr, ok := err.(*errWrapper); ok {
 		// if this err is already err wrapper, then we will unwrap it to reduce stack.
 		// Fields and tags were extracted in the previous step
 		err = wrappedErr.err
 	}
 
+	if stack == nil && captureStack {
+		// only the first wrap in the chain captures the stack, it is the closest one to the error origin
+		pcs := make([]uintptr, maxStackDepth)
+		stack = pcs[:runtime.Callers(callerSkip, pcs)]
+	}
+
 	return &errWrapper{
 		fields: ctxFields,
 		tags:   ctxTags,
+		stack:  stack,
 		err:    err,
 	}
 }
+
+// ErrorStack returns the stack trace captured by Logger.WrapError for err or any error in its tree.
+func ErrorStack(err error) []Frame {
+	return Frames(errorStack(err))
+}
+
+func errorStack(err error) []uintptr {
+	var stack []uintptr
+	walkError(err, func(e error) {
+		if wrappedErr, ok := e.(*errWrapper); ok && stack == nil {
+			stack = wrappedErr.stack
+		}
+	})
+	return stack
+}
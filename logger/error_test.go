@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestWrapErrorStack(t *testing.T) {
+	l := New(&recordDriver{}, WithErrorStack(true))
+	ctx := context.Background()
+
+	_, _, line, _ := runtime.Caller(0)
+	err := l.WrapError(ctx, errors.New("origin"))
+	err = l.WrapError(ctx, fmt.Errorf("outer: %w", err))
+
+	frames := ErrorStack(err)
+	if len(frames) == 0 {
+		t.Fatal("Expected stack to be captured")
+	}
+	if !strings.HasSuffix(frames[0].File, "error_test.go") || frames[0].Line != line+1 {
+		t.Errorf("Expected stack of the first wrap, got %s:%d", frames[0].File, frames[0].Line)
+	}
+
+	if ErrorStack(New(&recordDriver{}).WrapError(ctx, errors.New("origin"))) != nil {
+		t.Error("Expected stack not to be captured by default")
+	}
+}
+
+type fieldsError struct {
+	fields map[string]any
+	err    error
+}
+
+func (e *fieldsError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fieldsError) Unwrap() error {
+	return e.err
+}
+
+func (e *fieldsError) LoggerFields() map[string]any {
+	return e.fields
+}
+
+func TestErrorChainFields(t *testing.T) {
+	d := &fieldsDriver{}
+	l := New(d, WithErrorChain(true))
+	ctx := context.Background()
+
+	deep := &fieldsError{fields: map[string]any{"id": "deep", "deep": true}, err: errors.New("deep")}
+	branch := l.WrapError(l.WithTag(ctx, "branch", "yes"), errors.New("branch"))
+	err := error(&fieldsError{
+		fields: map[string]any{"id": "outer"},
+		err:    errors.Join(fmt.Errorf("middle: %w", deep), branch),
+	})
+
+	l.Info(ctx, "msg", err)
+
+	if d.fields["id"] != "outer" || d.fields["deep"] != true {
+		t.Errorf("Unexpected fields: %v", d.fields)
+	}
+	if d.tags["branch"] != "yes" {
+		t.Errorf("Expected tag from joined branch, got %v", d.tags)
+	}
+	chain, ok := d.fields[ErrorChainKey].([]ErrorCause)
+	if !ok || len(chain) != 5 || chain[0].Type != "*logger.fieldsError" || chain[1].Message != "middle: deep" || chain[4].Message != "branch" {
+		t.Errorf("Unexpected error chain: %v", d.fields[ErrorChainKey])
+	}
+}
+
+func TestErrorChainDisabled(t *testing.T) {
+	d := &fieldsDriver{}
+	l := New(d)
+
+	l.Info(context.Background(), "msg", fmt.Errorf("outer: %w", errors.New("inner")))
+
+	if d.fields == nil {
+		t.Fatal("Expected the event to be written")
+	}
+	if _, ok := d.fields[ErrorChainKey]; ok {
+		t.Errorf("Expected no error chain by default, got %v", d.fields[ErrorChainKey])
+	}
+}
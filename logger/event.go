@@ -0,0 +1,161 @@
+package logger
+
+import (
+	"iter"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// Event is an EventHandler which owns its data. Unlike the handler passed to the Driver,
+// whose maps are returned to the pool right after the call, the Event can be kept and used later.
+type Event struct {
+	level  Level
+	time   time.Time
+	msg    string
+	fields map[string]any
+	// attrs keep the typed attrs of the snapshot, the fields hold the rest
+	attrs  []Attr
+	tags   map[string]string
+	args   []any
+	err    error
+	req    *http.Request
+	caller *Caller
+}
+
+// NewEvent creates the event with InfoLevel and the current time.
+func NewEvent(msg string) *Event {
+	return &Event{
+		level:  InfoLevel,
+		time:   time.Now(),
+		msg:    msg,
+		fields: map[string]any{},
+		tags:   map[string]string{},
+	}
+}
+
+// Snapshot copies the data of h into a new Event, the typed attrs keep their kind.
+func Snapshot(h EventHandler) *Event {
+	e := &Event{
+		level:  h.Level(),
+		time:   h.Time(),
+		msg:    h.Msg(),
+		fields: map[string]any{},
+		tags:   maps.Collect(h.Tags()),
+		err:    h.Err(),
+		req:    h.Req(),
+		caller: h.Caller(),
+	}
+	for a := range h.Attrs() {
+		if a.Kind() == KindAny {
+			e.fields[a.Key] = a.Any()
+		} else {
+			e.attrs = append(e.attrs, a)
+		}
+	}
+	for _, arg := range h.Args() {
+		e.args = append(e.args, arg)
+	}
+	return e
+}
+
+func (e *Event) SetLevel(l Level) *Event {
+	e.level = l
+	return e
+}
+
+func (e *Event) SetTime(t time.Time) *Event {
+	e.time = t
+	return e
+}
+
+func (e *Event) SetField(k string, v any) *Event {
+	e.fields[k] = v
+	e.attrs = slices.DeleteFunc(e.attrs, func(a Attr) bool {
+		return a.Key == k
+	})
+	return e
+}
+
+func (e *Event) SetTag(k string, v string) *Event {
+	e.tags[k] = v
+	return e
+}
+
+func (e *Event) SetErr(err error) *Event {
+	e.err = err
+	return e
+}
+
+func (e *Event) SetReq(req *http.Request) *Event {
+	e.req = req
+	return e
+}
+
+func (e *Event) SetCaller(c *Caller) *Event {
+	e.caller = c
+	return e
+}
+
+func (e *Event) Level() Level {
+	return e.level
+}
+
+func (e *Event) Time() time.Time {
+	return e.time
+}
+
+func (e *Event) Msg() string {
+	return e.msg
+}
+
+func (e *Event) Fields() iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		for k, v := range e.fields {
+			if !yield(k, v) {
+				return
+			}
+		}
+		for _, a := range e.attrs {
+			if !yield(a.Key, a.Value()) {
+				return
+			}
+		}
+	}
+}
+
+func (e *Event) Attrs() iter.Seq[Attr] {
+	return func(yield func(Attr) bool) {
+		for k, v := range e.fields {
+			if !yield(Any(k, v)) {
+				return
+			}
+		}
+		for _, a := range e.attrs {
+			if !yield(a) {
+				return
+			}
+		}
+	}
+}
+
+func (e *Event) Tags() iter.Seq2[string, string] {
+	return maps.All(e.tags)
+}
+
+func (e *Event) Args() iter.Seq2[int, any] {
+	return slices.All(e.args)
+}
+
+func (e *Event) Err() error {
+	return e.err
+}
+
+func (e *Event) Req() *http.Request {
+	return e.req
+}
+
+func (e *Event) Caller() *Caller {
+	return e.caller
+}
@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// LazyValue is the field value which is computed only when the event is written.
+// The function is called at most once, however many drivers use the value.
+type LazyValue struct {
+	once sync.Once
+	f    func() any
+	v    any
+}
+
+// Lazy defers the computation of the expensive field value, e.g. l.Debug(ctx, "msg", l.Field("dump", logger.Lazy(dump))).
+func Lazy(f func() any) *LazyValue {
+	return &LazyValue{f: f}
+}
+
+func (l *LazyValue) Value() any {
+	l.once.Do(func() {
+		l.v = l.f()
+		l.f = nil
+	})
+	return l.v
+}
+
+func (l *LazyValue) LogValue() slog.Value {
+	return slog.AnyValue(l.Value())
+}
+
+func (l *LazyValue) Format(s fmt.State, verb rune) {
+	_, _ = fmt.Fprintf(s, fmt.FormatString(s, verb), l.Value())
+}
+
+// Resolve returns the computed value if v is LazyValue and v itself otherwise.
+func Resolve(v any) any {
+	if l, ok := v.(*LazyValue); ok {
+		return l.Value()
+	}
+	return v
+}
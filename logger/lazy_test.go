@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"context"
+	"testing"
+)
+
+type resolveDriver struct {
+	recordDriver
+}
+
+func (d *resolveDriver) Debug(ctx context.Context, h EventHandler) {
+	for _, v := range h.Fields() {
+		Resolve(v)
+	}
+	d.write(h)
+}
+
+func TestLazy(t *testing.T) {
+	d := &resolveDriver{}
+	l := New(d, WithMinLevel(InfoLevel))
+
+	calls := 0
+	value := func() any {
+		calls++
+		return "expensive"
+	}
+
+	ctx := context.Background()
+	l.Debug(ctx, "skipped", l.Field("dump", Lazy(value)))
+	l.Debugf(ctx, "skipped %v", Lazy(value))
+	if calls != 0 {
+		t.Errorf("Expected lazy value not to be computed, got %d calls", calls)
+	}
+
+	lazy := Lazy(value)
+	ctx = l.WithLevel(ctx, DebugLevel)
+	l.Debug(ctx, "written", l.Field("dump", lazy))
+	l.Debugf(ctx, "written %v", lazy)
+	if calls != 1 {
+		t.Errorf("Expected lazy value to be computed once, got %d calls", calls)
+	}
+	if len(d.msgs) != 2 || d.msgs[1] != "written expensive" {
+		t.Errorf("Unexpected messages: %v", d.msgs)
+	}
+}
@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+type Level int8
+
+const (
+	TraceLevel Level = iota
+	DebugLevel
+	InfoLevel
+	WarningLevel
+	ErrorLevel
+	FatalLevel
+)
+
+func (l Level) String() string {
+	switch l {
+	case TraceLevel:
+		return "trace"
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarningLevel:
+		return "warning"
+	case ErrorLevel:
+		return "error"
+	case FatalLevel:
+		return "fatal"
+	default:
+		return fmt.Sprintf("Level(%d)", l)
+	}
+}
+
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+func (l *Level) UnmarshalText(text []byte) error {
+	parsed, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = parsed
+	return nil
+}
+
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "trace":
+		return TraceLevel, nil
+	case "debug":
+		return DebugLevel, nil
+	case "info":
+		return InfoLevel, nil
+	case "warning", "warn":
+		return WarningLevel, nil
+	case "error":
+		return ErrorLevel, nil
+	case "fatal":
+		return FatalLevel, nil
+	default:
+		return TraceLevel, fmt.Errorf("unknown log level %q", s)
+	}
+}
+
+// AtomicLevel is a minimum log level which can be safely changed while the process is running.
+// One AtomicLevel can be shared between several loggers.
+type AtomicLevel struct {
+	l atomic.Int32
+}
+
+func NewAtomicLevel(l Level) *AtomicLevel {
+	a := &AtomicLevel{}
+	a.SetLevel(l)
+	return a
+}
+
+func (a *AtomicLevel) Level() Level {
+	return Level(a.l.Load())
+}
+
+func (a *AtomicLevel) SetLevel(l Level) {
+	a.l.Store(int32(l))
+}
+
+func (a *AtomicLevel) Enabled(l Level) bool {
+	return l >= a.Level()
+}
+
+func (a *AtomicLevel) String() string {
+	return a.Level().String()
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+type levelPayload struct {
+	Level Level `json:"level"`
+}
+
+type errorPayload struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP allows to read and change the level at runtime, e.g. mux.Handle("/loglevel", level).
+//
+// GET returns the current level: {"level":"info"}.
+// PUT changes the level, the new one is read from a JSON body ({"level":"debug"})
+// or from the "level" form value (curl -X PUT "localhost:8080/loglevel?level=debug").
+func (a *AtomicLevel) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		l, err := decodeLevel(r)
+		if err != nil {
+			writeLevelResponse(w, http.StatusBadRequest, errorPayload{Error: err.Error()})
+			return
+		}
+		a.SetLevel(l)
+	default:
+		w.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodPut}, ", "))
+		writeLevelResponse(w, http.StatusMethodNotAllowed, errorPayload{Error: "only GET and PUT are supported"})
+		return
+	}
+
+	writeLevelResponse(w, http.StatusOK, levelPayload{Level: a.Level()})
+}
+
+func decodeLevel(r *http.Request) (Level, error) {
+	if v := r.FormValue("level"); v != "" {
+		return ParseLevel(v)
+	}
+
+	var p struct {
+		Level *Level `json:"level"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		return TraceLevel, err
+	}
+	if p.Level == nil {
+		return TraceLevel, errors.New("level must be specified")
+	}
+	return *p.Level, nil
+}
+
+func writeLevelResponse(w http.ResponseWriter, code int, payload any) {
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(payload)
+}
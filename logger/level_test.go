@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	for _, l := range []Level{TraceLevel, DebugLevel, InfoLevel, WarningLevel, ErrorLevel, FatalLevel} {
+		parsed, err := ParseLevel(l.String())
+		if err != nil {
+			t.Fatalf("ParseLevel(%q) returned error: %v", l.String(), err)
+		}
+		if parsed != l {
+			t.Errorf("Expected level %s, got %s", l, parsed)
+		}
+	}
+
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("Expected error for unknown level")
+	}
+}
+
+func TestAtomicLevelServeHTTP(t *testing.T) {
+	level := NewAtomicLevel(InfoLevel)
+
+	rec := httptest.NewRecorder()
+	level.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/loglevel", nil))
+	if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != `{"level":"info"}` {
+		t.Errorf("Unexpected GET response: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	level.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"debug"}`)))
+	if rec.Code != http.StatusOK || level.Level() != DebugLevel {
+		t.Errorf("Expected level to be changed to debug, got %d %s", rec.Code, level.Level())
+	}
+
+	rec = httptest.NewRecorder()
+	level.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/loglevel?level=error", nil))
+	if rec.Code != http.StatusOK || level.Level() != ErrorLevel {
+		t.Errorf("Expected level to be changed to error, got %d %s", rec.Code, level.Level())
+	}
+
+	rec = httptest.NewRecorder()
+	level.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"loud"}`)))
+	if rec.Code != http.StatusBadRequest || level.Level() != ErrorLevel {
+		t.Errorf("Expected bad request and unchanged level, got %d %s", rec.Code, level.Level())
+	}
+
+	rec = httptest.NewRecorder()
+	level.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/loglevel", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected method not allowed, got %d", rec.Code)
+	}
+}
+
+func TestAtomicLevelDropsEvents(t *testing.T) {
+	d := &recordDriver{}
+	level := NewAtomicLevel(WarningLevel)
+	l := New(d, WithAtomicLevel(level))
+
+	ctx := context.Background()
+	l.Debug(ctx, "dropped")
+	l.Info(ctx, "dropped")
+	l.Warning(ctx, "warning")
+
+	level.SetLevel(DebugLevel)
+	l.Debug(ctx, "debug")
+	l.Trace(ctx, "dropped")
+
+	if strings.Join(d.msgs, ",") != "warning,debug" {
+		t.Errorf("Expected the events below the level not to reach the driver, got %q", d.msgs)
+	}
+}
@@ -2,11 +2,12 @@ package logger
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"iter"
 	"maps"
 	"net/http"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/Pacman29/observability/internal/pool"
@@ -14,10 +15,11 @@ import (
 
 type logger struct {
 	d          Driver
+	handlers   *sync.Pool
 	fieldsPool *pool.Map[string, any]
 	tagsPool   *pool.Map[string, string]
-	argsPool   *pool.Slice[any]
 	o          *options
+	bound      *bindings
 }
 
 func New(d Driver, opts ...Option) Logger {
@@ -27,36 +29,58 @@ func New(d Driver, opts ...Option) Logger {
 	}
 
 	return &logger{
-		d:          d,
+		d:          Chain(d, o.middlewares...),
 		fieldsPool: pool.NewMap[string, any](o.fieldsMapPoolSaveCapacity, o.fieldsMapPoolCreateCapacity, o.defaultFields),
 		tagsPool:   pool.NewMap[string, string](o.tagsMapPoolSaveCapacity, o.tagsMapPoolCreateCapacity, o.defaultTags),
-		argsPool:   pool.NewSlice[any](o.argsArrayPoolSaveCapacity, o.argsArrayPoolCreateCapacity, nil),
-		o:          o,
+		// args and attrs are kept by the pooled handler, putting a slice into sync.Pool would allocate
+		handlers: &sync.Pool{New: func() any {
+			return &logEventHandler{
+				args:  make([]any, 0, o.argsArrayPoolCreateCapacity),
+				attrs: make([]Attr, 0, o.argsArrayPoolCreateCapacity),
+			}
+		}},
+		o: o,
 	}
 }
 
 type logEventHandler struct {
+	level  Level
+	time   time.Time
 	msg    string
 	fields map[string]any
 	tags   map[string]string
 	args   []any
+	// attrs win over the fields with the same key
+	attrs  []Attr
 	err    error
 	req    *http.Request
+	caller *Caller
+}
+
+// newHandler takes the handler from the pool, it must be returned by releaseHandler after the driver call.
+func (l *logger) newHandler(level Level, msg string) *logEventHandler {
+	h := l.handlers.Get().(*logEventHandler)
+	h.level = level
+	h.time = time.Now()
+	h.msg = msg
+	h.fields = l.fieldsPool.Get()
+	h.tags = l.tagsPool.Get()
+	return h
 }
 
-func (l *logger) newHandler(msg string) (*logEventHandler, func()) {
-	h := &logEventHandler{
-		msg:    msg,
-		fields: l.fieldsPool.Get(),
-		tags:   l.tagsPool.Get(),
-		args:   l.argsPool.Get(),
-		err:    nil,
+func (l *logger) releaseHandler(h *logEventHandler) {
+	l.fieldsPool.Save(h.fields)
+	l.tagsPool.Save(h.tags)
+	if len(h.args) > l.o.argsArrayPoolSaveCapacity || len(h.attrs) > l.o.argsArrayPoolSaveCapacity {
+		return
 	}
-	return h, func() {
-		l.fieldsPool.Save(h.fields)
-		l.tagsPool.Save(h.tags)
-		l.argsPool.Save(h.args)
+	clear(h.args)
+	clear(h.attrs)
+	*h = logEventHandler{
+		args:  h.args[:0],
+		attrs: h.attrs[:0],
 	}
+	l.handlers.Put(h)
 }
 
 func (h *logEventHandler) resolveArgs(args ...any) {
@@ -66,6 +90,8 @@ func (h *logEventHandler) resolveArgs(args ...any) {
 			h.fields[argument.k] = argument.v
 		case *tag:
 			h.tags[argument.k] = argument.v
+		case Attr:
+			h.addAttr(argument)
 		case error:
 			h.err = argument
 		default:
@@ -74,8 +100,56 @@ func (h *logEventHandler) resolveArgs(args ...any) {
 	}
 }
 
+func (h *logEventHandler) addAttr(a Attr) {
+	delete(h.fields, a.Key)
+	for i := range h.attrs {
+		if h.attrs[i].Key == a.Key {
+			h.attrs[i] = a
+			return
+		}
+	}
+	h.attrs = append(h.attrs, a)
+}
+
+func lastError(args []any) error {
+	for _, arg := range slices.Backward(args) {
+		if err, ok := arg.(error); ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// Fields yields the fields and the values of the attrs.
 func (h *logEventHandler) Fields() iter.Seq2[string, any] {
-	return maps.All(h.fields)
+	return func(yield func(string, any) bool) {
+		for k, v := range h.fields {
+			if !yield(k, v) {
+				return
+			}
+		}
+		for _, a := range h.attrs {
+			if !yield(a.Key, a.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Attrs yields the attrs and the fields as attrs of KindAny.
+func (h *logEventHandler) Attrs() iter.Seq[Attr] {
+	return func(yield func(Attr) bool) {
+		for k, v := range h.fields {
+			if !yield(Any(k, v)) {
+				return
+			}
+		}
+		for _, a := range h.attrs {
+			if !yield(a) {
+				return
+			}
+		}
+	}
 }
 
 func (h *logEventHandler) Args() iter.Seq2[int, any] {
@@ -90,6 +164,14 @@ func (h *logEventHandler) Err() error {
 	return h.err
 }
 
+func (h *logEventHandler) Level() Level {
+	return h.level
+}
+
+func (h *logEventHandler) Time() time.Time {
+	return h.time
+}
+
 func (h *logEventHandler) Msg() string {
 	return h.msg
 }
@@ -98,90 +180,262 @@ func (h *logEventHandler) Req() *http.Request {
 	return h.req
 }
 
+func (h *logEventHandler) Caller() *Caller {
+	return h.caller
+}
+
 func (l *logger) withArgs(ctx context.Context, handler *logEventHandler, args ...any) {
+	// сначала данные дочернего логгера
+	handler.resolveBindings(l.bound)
+
 	// добавляем данные из ридеров
 	for _, reader := range l.o.ctxReaders {
 		handler.resolveArgs(reader(ctx)...)
 	}
 
-	// потом все из контекста
+	// потом все из контекста, из общих тегов только разрешенные
 	for k, v := range getFields(ctx) {
 		handler.fields[k] = v
 	}
-	for k, v := range getTags(ctx) {
+	l.o.rangeTags(ctx, func(k string, v string) {
 		handler.tags[k] = v
+	})
+	handler.req = getRequest(ctx)
+
+	// потом все из ошибки: из аргументов, если она там есть, иначе из контекста или дочернего логгера
+	err := lastError(args)
+	if err == nil {
+		err = getError(ctx)
 	}
-	handler.err = getError(ctx)
-	// потом все из ошибки
-	if handler.err != nil {
-		var wErr *errWrapper
-		if errors.As(handler.err, &wErr) {
-			for k, v := range wErr.fields {
-				handler.fields[k] = v
-			}
-			for k, v := range wErr.tags {
-				handler.tags[k] = v
+	if err == nil {
+		err = handler.err
+	}
+	handler.err = err
+	if err != nil {
+		errFields, errTags := errorData(err)
+		maps.Copy(handler.fields, errFields)
+		maps.Copy(handler.tags, errTags)
+		if l.o.errorChain {
+			if chain := errorChain(err); len(chain) > 1 {
+				handler.fields[ErrorChainKey] = chain
 			}
 		}
 	}
-	handler.req = getRequest(ctx)
 
 	// вытаскиваем все из аргументов текущих
 	handler.resolveArgs(args...)
+
+	// и прячем чувствительные данные перед передачей в драйвер
+	if l.o.redactor != nil {
+		l.o.redactor.redactHandler(handler)
+	}
+}
+
+// enabled reports whether the event with the level should be passed to the driver.
+// The level from the context (see WithLevel) can only lower the logger level, so the lower of them is used.
+func (l *logger) enabled(ctx context.Context, level Level) bool {
+	if ctxLevel, ok := getLevel(ctx); ok && level >= ctxLevel {
+		return true
+	}
+	return l.o.level.Enabled(level)
 }
 
 func (l *logger) Trace(ctx context.Context, msg string, args ...any) {
 	ctx = defaultCtx(ctx)
-	h, handlerClose := l.newHandler(msg)
-	defer handlerClose()
+	if !l.enabled(ctx, TraceLevel) {
+		return
+	}
+	h := l.newHandler(TraceLevel, msg)
+	defer l.releaseHandler(h)
 	l.withArgs(ctx, h, args...)
+	h.caller = l.caller(callerSkip)
 
 	l.d.Trace(ctx, h)
 }
 
 func (l *logger) Debug(ctx context.Context, msg string, args ...any) {
 	ctx = defaultCtx(ctx)
-	h, handlerClose := l.newHandler(msg)
-	defer handlerClose()
+	if !l.enabled(ctx, DebugLevel) {
+		return
+	}
+	h := l.newHandler(DebugLevel, msg)
+	defer l.releaseHandler(h)
 	l.withArgs(ctx, h, args...)
+	h.caller = l.caller(callerSkip)
 
 	l.d.Debug(ctx, h)
 }
 
 func (l *logger) Info(ctx context.Context, msg string, args ...any) {
 	ctx = defaultCtx(ctx)
-	h, handlerClose := l.newHandler(msg)
-	defer handlerClose()
+	if !l.enabled(ctx, InfoLevel) {
+		return
+	}
+	h := l.newHandler(InfoLevel, msg)
+	defer l.releaseHandler(h)
 	l.withArgs(ctx, h, args...)
+	h.caller = l.caller(callerSkip)
 
 	l.d.Info(ctx, h)
 }
 
 func (l *logger) Warning(ctx context.Context, msg string, args ...any) {
 	ctx = defaultCtx(ctx)
-	h, handlerClose := l.newHandler(msg)
-	defer handlerClose()
+	if !l.enabled(ctx, WarningLevel) {
+		return
+	}
+	h := l.newHandler(WarningLevel, msg)
+	defer l.releaseHandler(h)
 	l.withArgs(ctx, h, args...)
+	h.caller = l.caller(callerSkip)
 
 	l.d.Warning(ctx, h)
 }
 
 func (l *logger) Error(ctx context.Context, msg string, args ...any) {
 	ctx = defaultCtx(ctx)
-	h, handlerClose := l.newHandler(msg)
-	defer handlerClose()
+	if !l.enabled(ctx, ErrorLevel) {
+		return
+	}
+	h := l.newHandler(ErrorLevel, msg)
+	defer l.releaseHandler(h)
 	l.withArgs(ctx, h, args...)
+	h.caller = l.caller(callerSkip)
 
 	l.d.Error(ctx, h)
 }
 
 func (l *logger) Fatal(ctx context.Context, msg string, args ...any) {
 	ctx = defaultCtx(ctx)
-	h, handlerClose := l.newHandler(msg)
-	defer handlerClose()
+	h := l.newHandler(FatalLevel, msg)
+	defer l.releaseHandler(h)
 	l.withArgs(ctx, h, args...)
+	h.caller = l.caller(callerSkip)
+
+	l.d.Fatal(ctx, h)
+	// драйверы обычно сами завершают процесс, но middleware может не передать событие дальше
+	l.o.exit(1)
+}
+
+func (l *logger) Tracef(ctx context.Context, format string, args ...any) {
+	ctx = defaultCtx(ctx)
+	if !l.enabled(ctx, TraceLevel) {
+		return
+	}
+	h := l.newHandler(TraceLevel, fmt.Sprintf(format, args...))
+	defer l.releaseHandler(h)
+	l.withArgs(ctx, h)
+	h.caller = l.caller(callerSkip)
+
+	l.d.Trace(ctx, h)
+}
+
+func (l *logger) Debugf(ctx context.Context, format string, args ...any) {
+	ctx = defaultCtx(ctx)
+	if !l.enabled(ctx, DebugLevel) {
+		return
+	}
+	h := l.newHandler(DebugLevel, fmt.Sprintf(format, args...))
+	defer l.releaseHandler(h)
+	l.withArgs(ctx, h)
+	h.caller = l.caller(callerSkip)
+
+	l.d.Debug(ctx, h)
+}
+
+func (l *logger) Infof(ctx context.Context, format string, args ...any) {
+	ctx = defaultCtx(ctx)
+	if !l.enabled(ctx, InfoLevel) {
+		return
+	}
+	h := l.newHandler(InfoLevel, fmt.Sprintf(format, args...))
+	defer l.releaseHandler(h)
+	l.withArgs(ctx, h)
+	h.caller = l.caller(callerSkip)
+
+	l.d.Info(ctx, h)
+}
+
+func (l *logger) Warningf(ctx context.Context, format string, args ...any) {
+	ctx = defaultCtx(ctx)
+	if !l.enabled(ctx, WarningLevel) {
+		return
+	}
+	h := l.newHandler(WarningLevel, fmt.Sprintf(format, args...))
+	defer l.releaseHandler(h)
+	l.withArgs(ctx, h)
+	h.caller = l.caller(callerSkip)
+
+	l.d.Warning(ctx, h)
+}
+
+func (l *logger) Errorf(ctx context.Context, format string, args ...any) {
+	ctx = defaultCtx(ctx)
+	if !l.enabled(ctx, ErrorLevel) {
+		return
+	}
+	h := l.newHandler(ErrorLevel, fmt.Sprintf(format, args...))
+	defer l.releaseHandler(h)
+	l.withArgs(ctx, h)
+	h.caller = l.caller(callerSkip)
+
+	l.d.Error(ctx, h)
+}
+
+func (l *logger) Fatalf(ctx context.Context, format string, args ...any) {
+	ctx = defaultCtx(ctx)
+	h := l.newHandler(FatalLevel, fmt.Sprintf(format, args...))
+	defer l.releaseHandler(h)
+	l.withArgs(ctx, h)
+	h.caller = l.caller(callerSkip)
 
 	l.d.Fatal(ctx, h)
+	l.o.exit(1)
+}
+
+// Log writes the event with the level chosen at runtime, FatalLevel terminates the process as Fatal does.
+func (l *logger) Log(ctx context.Context, level Level, msg string, args ...any) {
+	l.logDepth(ctx, level, msg, 1, args...)
+}
+
+// logDepth is Log which takes the caller skip frames above its own caller, e.g. for the bridges
+// which are called by another logging package.
+func (l *logger) logDepth(ctx context.Context, level Level, msg string, skip int, args ...any) {
+	ctx = defaultCtx(ctx)
+	if !l.enabled(ctx, level) {
+		return
+	}
+	h := l.newHandler(level, msg)
+	defer l.releaseHandler(h)
+	l.withArgs(ctx, h, args...)
+	h.caller = l.caller(callerSkip + skip)
+
+	Dispatch(ctx, l.d, level, h)
+	if level == FatalLevel {
+		l.o.exit(1)
+	}
+}
+
+func (l *logger) LogAttrs(ctx context.Context, level Level, msg string, attrs ...Attr) {
+	ctx = defaultCtx(ctx)
+	if !l.enabled(ctx, level) {
+		return
+	}
+	h := l.newHandler(level, msg)
+	defer l.releaseHandler(h)
+	l.withArgs(ctx, h)
+	for _, a := range attrs {
+		h.addAttr(a)
+	}
+	if l.o.redactor != nil {
+		h.attrs = l.o.redactor.RedactAttrs(h.attrs)
+	}
+	h.caller = l.caller(callerSkip)
+
+	Dispatch(ctx, l.d, level, h)
+	if level == FatalLevel {
+		l.o.exit(1)
+	}
 }
 
 func (l *logger) Recover(ctx context.Context) {
@@ -191,9 +445,10 @@ func (l *logger) Recover(ctx context.Context) {
 	}
 
 	ctx = defaultCtx(ctx)
-	h, handlerClose := l.newHandler("")
-	defer handlerClose()
+	h := l.newHandler(ErrorLevel, "")
+	defer l.releaseHandler(h)
 	l.withArgs(ctx, h)
+	h.caller = l.panicCaller(callerSkip)
 
 	l.d.Recover(err, ctx, h)
 }
@@ -230,7 +485,7 @@ func (l *logger) WithTags(ctx context.Context, tags map[string]string) context.C
 
 func (l *logger) Tags(ctx context.Context) map[string]string {
 	ctx = defaultCtx(ctx)
-	return getCtxTags(ctx)
+	return getCtxTags(ctx, l.o)
 }
 
 func (l *logger) WithContext(ctx context.Context, src context.Context) context.Context {
@@ -243,9 +498,14 @@ func (l *logger) WithRequest(ctx context.Context, request *http.Request) context
 	return addRequestToCtx(ctx, request)
 }
 
+func (l *logger) WithLevel(ctx context.Context, level Level) context.Context {
+	ctx = defaultCtx(ctx)
+	return addLevelToCtx(ctx, level)
+}
+
 func (l *logger) WrapError(ctx context.Context, err error) error {
 	ctx = defaultCtx(ctx)
-	return wrapError(ctx, err)
+	return wrapError(ctx, err, getCtxTags(ctx, l.o), l.o.errorStack)
 }
 
 func (l *logger) Field(k string, v any) any {
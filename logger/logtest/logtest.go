@@ -0,0 +1,220 @@
+// Package logtest provides the recording driver for the tests of the code which uses the logger,
+// so the events can be checked without a hand-written mock of logger.Logger.
+//
+//	l, rec := logtest.NewLogger()
+//	svc := NewService(l)
+//	svc.Retry(ctx)
+//	rec.AssertLogged(t, logger.WarningLevel, "retry", logtest.WithTag("attempt", "2"))
+package logtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+// Entry is a copy of the recorded event, the lazy values of the fields and args are resolved.
+type Entry struct {
+	Level  logger.Level
+	Time   time.Time
+	Msg    string
+	Fields map[string]any
+	Tags   map[string]string
+	Args   []any
+	Err    error
+	Req    *http.Request
+	Caller *logger.Caller
+	// Panic is the recovered value for the events of Recover
+	Panic any
+}
+
+func (e Entry) String() string {
+	return fmt.Sprintf("%s %q fields=%v tags=%v args=%v err=%v", e.Level, e.Msg, e.Fields, e.Tags, e.Args, e.Err)
+}
+
+// Recorder is the logger.Driver which keeps all events in memory. The data of the events is copied,
+// since the logger reuses it after the driver returns.
+type Recorder struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// New returns an empty recorder.
+func New() *Recorder {
+	return &Recorder{}
+}
+
+// NewLogger returns the logger which writes to a new recorder. Fatal doesn't exit the process,
+// its event is recorded with logger.FatalLevel.
+func NewLogger(opts ...logger.Option) (logger.Logger, *Recorder) {
+	r := New()
+	opts = append(slices.Clip(opts), logger.WithExitFunc(func(code int) {}))
+	return logger.New(r, opts...), r
+}
+
+func (r *Recorder) record(level logger.Level, h logger.EventHandler, panicValue any) {
+	e := Entry{
+		Level:  level,
+		Time:   h.Time(),
+		Msg:    h.Msg(),
+		Fields: map[string]any{},
+		Tags:   maps.Collect(h.Tags()),
+		Err:    h.Err(),
+		Req:    h.Req(),
+		Panic:  panicValue,
+	}
+	for k, v := range h.Fields() {
+		e.Fields[k] = logger.Resolve(v)
+	}
+	for _, v := range h.Args() {
+		e.Args = append(e.Args, logger.Resolve(v))
+	}
+	if c := h.Caller(); c != nil {
+		caller := *c
+		caller.Stack = slices.Clone(c.Stack)
+		e.Caller = &caller
+	}
+
+	r.mu.Lock()
+	r.entries = append(r.entries, e)
+	r.mu.Unlock()
+}
+
+func (r *Recorder) Trace(ctx context.Context, h logger.EventHandler) {
+	r.record(logger.TraceLevel, h, nil)
+}
+
+func (r *Recorder) Debug(ctx context.Context, h logger.EventHandler) {
+	r.record(logger.DebugLevel, h, nil)
+}
+
+func (r *Recorder) Info(ctx context.Context, h logger.EventHandler) {
+	r.record(logger.InfoLevel, h, nil)
+}
+
+func (r *Recorder) Warning(ctx context.Context, h logger.EventHandler) {
+	r.record(logger.WarningLevel, h, nil)
+}
+
+func (r *Recorder) Error(ctx context.Context, h logger.EventHandler) {
+	r.record(logger.ErrorLevel, h, nil)
+}
+
+func (r *Recorder) Fatal(ctx context.Context, h logger.EventHandler) {
+	r.record(logger.FatalLevel, h, nil)
+}
+
+func (r *Recorder) Flush(timeout time.Duration) error {
+	return nil
+}
+
+// Recover records the panic with logger.ErrorLevel, the value is kept in Entry.Panic.
+func (r *Recorder) Recover(err any, ctx context.Context, h logger.EventHandler) {
+	r.record(logger.ErrorLevel, h, err)
+}
+
+// Entries returns the recorded events in the order of the calls.
+func (r *Recorder) Entries() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.entries)
+}
+
+// Reset drops the recorded events.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.entries = nil
+	r.mu.Unlock()
+}
+
+// Matcher is an additional condition of Find and the assertions.
+type Matcher func(e Entry) bool
+
+// WithTag matches the entries with the tag k equal to v.
+func WithTag(k, v string) Matcher {
+	return func(e Entry) bool {
+		tag, ok := e.Tags[k]
+		return ok && tag == v
+	}
+}
+
+// WithField matches the entries with the field k equal to v. The values of different types are compared
+// by their text, so WithField("rows", 10) matches logger.Int64("rows", 10).
+func WithField(k string, v any) Matcher {
+	return func(e Entry) bool {
+		field, ok := e.Fields[k]
+		return ok && (reflect.DeepEqual(field, v) || fmt.Sprint(field) == fmt.Sprint(v))
+	}
+}
+
+// WithError matches the entries with the error which is target by errors.Is.
+func WithError(target error) Matcher {
+	return func(e Entry) bool {
+		return errors.Is(e.Err, target)
+	}
+}
+
+// Find returns the entries of level which message contains msg and which match all matchers.
+func (r *Recorder) Find(level logger.Level, msg string, matchers ...Matcher) []Entry {
+	var found []Entry
+	for _, e := range r.Entries() {
+		if e.Level != level || !strings.Contains(e.Msg, msg) {
+			continue
+		}
+		matched := true
+		for _, m := range matchers {
+			if !m(e) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// AssertLogged fails t if no entry of level contains msg and matches all matchers, the first found entry is returned.
+func (r *Recorder) AssertLogged(t testing.TB, level logger.Level, msg string, matchers ...Matcher) Entry {
+	t.Helper()
+
+	found := r.Find(level, msg, matchers...)
+	if len(found) == 0 {
+		t.Errorf("Expected %s entry with %q, got:%s", level, msg, r.dump())
+		return Entry{}
+	}
+	return found[0]
+}
+
+// AssertNotLogged fails t if any entry of level contains msg and matches all matchers.
+func (r *Recorder) AssertNotLogged(t testing.TB, level logger.Level, msg string, matchers ...Matcher) {
+	t.Helper()
+
+	if found := r.Find(level, msg, matchers...); len(found) != 0 {
+		t.Errorf("Expected no %s entry with %q, got: %v", level, msg, found)
+	}
+}
+
+func (r *Recorder) dump() string {
+	entries := r.Entries()
+	if len(entries) == 0 {
+		return " no entries"
+	}
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString("\n\t")
+		b.WriteString(e.String())
+	}
+	return b.String()
+}
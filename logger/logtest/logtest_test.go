@@ -0,0 +1,120 @@
+package logtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Pacman29/observability/logger"
+	"github.com/Pacman29/observability/logger/drivertest"
+)
+
+// failT records the failures of the assertions instead of failing the test.
+type failT struct {
+	testing.TB
+	failed bool
+}
+
+func (t *failT) Helper() {}
+
+func (t *failT) Errorf(format string, args ...any) {
+	t.failed = true
+}
+
+func TestAssertLogged(t *testing.T) {
+	l, rec := NewLogger()
+	ctx := l.WithTag(context.Background(), "attempt", "2")
+	errTimeout := errors.New("timeout")
+
+	l.Warning(ctx, "retry request", logger.Int("rows", 10), errTimeout)
+
+	e := rec.AssertLogged(t, logger.WarningLevel, "retry", WithTag("attempt", "2"), WithField("rows", 10), WithError(errTimeout))
+	if e.Msg != "retry request" {
+		t.Errorf("Expected the found entry, got %v", e)
+	}
+	rec.AssertNotLogged(t, logger.ErrorLevel, "retry")
+
+	checks := map[string]func(t testing.TB){
+		"level": func(t testing.TB) {
+			rec.AssertLogged(t, logger.InfoLevel, "retry")
+		},
+		"message": func(t testing.TB) {
+			rec.AssertLogged(t, logger.WarningLevel, "success")
+		},
+		"tag": func(t testing.TB) {
+			rec.AssertLogged(t, logger.WarningLevel, "retry", WithTag("attempt", "3"))
+		},
+		"not logged": func(t testing.TB) {
+			rec.AssertNotLogged(t, logger.WarningLevel, "retry")
+		},
+	}
+	for name, check := range checks {
+		ft := &failT{TB: t}
+		check(ft)
+		if !ft.failed {
+			t.Errorf("%s: expected the assertion to fail", name)
+		}
+	}
+}
+
+func TestEntriesAreCopied(t *testing.T) {
+	l, rec := NewLogger()
+	ctx := context.Background()
+
+	l.Info(ctx, "first", l.Field("id", 1), l.Tag("user", "alice"), "k", "v")
+	l.Info(ctx, "second", l.Field("id", 2), l.Tag("user", "bob"), "k", "w")
+
+	entries := rec.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Fields["id"] != 1 || entries[0].Tags["user"] != "alice" || fmt.Sprint(entries[0].Args) != "[k v]" {
+		t.Errorf("Expected the first entry to keep its data, got %v", entries[0])
+	}
+
+	rec.Reset()
+	if len(rec.Entries()) != 0 {
+		t.Error("Expected no entries after Reset")
+	}
+}
+
+func TestFatal(t *testing.T) {
+	l, rec := NewLogger()
+
+	l.Fatal(context.Background(), "fatal")
+
+	rec.AssertLogged(t, logger.FatalLevel, "fatal")
+}
+
+func TestDriver(t *testing.T) {
+	drivertest.Run(t, func(t *testing.T, exit func(code int)) drivertest.Harness {
+		rec := New()
+		return drivertest.Harness{
+			Driver: rec,
+			Entries: func() []drivertest.Entry {
+				var entries []drivertest.Entry
+				for _, e := range rec.Entries() {
+					entry := drivertest.Entry{
+						Level:  e.Level,
+						Msg:    e.Msg,
+						Fields: e.Fields,
+						Tags:   e.Tags,
+						Args:   e.Args,
+					}
+					if e.Err != nil {
+						entry.Err = e.Err.Error()
+					}
+					if e.Req != nil {
+						entry.Request = e.Req.URL.String()
+					}
+					if e.Panic != nil {
+						entry.Panic = fmt.Sprint(e.Panic)
+					}
+					entries = append(entries, entry)
+				}
+				return entries
+			},
+		}
+	}, drivertest.WithoutExit())
+}
@@ -0,0 +1,29 @@
+package logger
+
+// Middleware wraps the driver to enrich, filter, mutate or route events before they reach it.
+// Wrapping drivers of this module fit it as well:
+//
+//	logger.WithMiddleware(func(next logger.Driver) logger.Driver {
+//		return sampling.NewSamplingDriver(next)
+//	})
+//
+// The Logger calls the method of the level for each event, Log and LogAttrs included, so a middleware
+// may override only the methods of the levels it handles.
+//
+// The EventHandler passed to the middleware is valid only during the call, a middleware which changes
+// the event or keeps it for later has to work with a copy (see Snapshot).
+//
+// Fatal: the Logger terminates the process after the chain returns from Fatal, so a middleware
+// which drops the Fatal event doesn't prevent the exit, it only prevents writing the event.
+//
+// Recover: the panic is already recovered by Logger.Recover when the chain is called, the middleware
+// receives the panic value and must not panic again. Dropping the Recover call suppresses the report only.
+type Middleware func(next Driver) Driver
+
+// Chain wraps d with the middlewares. The first middleware is the outermost one, it receives the event first.
+func Chain(d Driver, mws ...Middleware) Driver {
+	for i := len(mws) - 1; i >= 0; i-- {
+		d = mws[i](d)
+	}
+	return d
+}
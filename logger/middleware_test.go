@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"context"
+	"testing"
+)
+
+type prefixDriver struct {
+	Driver
+	prefix string
+}
+
+func (d *prefixDriver) Info(ctx context.Context, h EventHandler) {
+	d.Driver.Info(ctx, NewEvent(d.prefix+h.Msg()))
+}
+
+type dropFatalDriver struct {
+	Driver
+}
+
+func (d *dropFatalDriver) Fatal(ctx context.Context, h EventHandler) {}
+
+func prefixMiddleware(prefix string) Middleware {
+	return func(next Driver) Driver {
+		return &prefixDriver{Driver: next, prefix: prefix}
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	d := &recordDriver{}
+	l := New(d, WithMiddleware(prefixMiddleware("first:")), WithMiddleware(prefixMiddleware("second:")))
+	l.Info(context.Background(), "msg")
+
+	if len(d.msgs) != 1 || d.msgs[0] != "second:first:msg" {
+		t.Errorf("Unexpected messages: %v", d.msgs)
+	}
+}
+
+func TestMiddlewareLog(t *testing.T) {
+	d := &recordDriver{}
+	l := New(d, WithMiddleware(prefixMiddleware("mw:")))
+
+	ctx := context.Background()
+	l.Info(ctx, "a")
+	l.Log(ctx, InfoLevel, "b")
+	l.LogAttrs(ctx, InfoLevel, "c", Int("n", 1))
+
+	if len(d.msgs) != 3 || d.msgs[0] != "mw:a" || d.msgs[1] != "mw:b" || d.msgs[2] != "mw:c" {
+		t.Errorf("Expected all events to pass the middleware, got %v", d.msgs)
+	}
+}
+
+func TestMiddlewareDropFatal(t *testing.T) {
+	d := &recordDriver{}
+	code := -1
+	l := New(d,
+		WithMiddleware(func(next Driver) Driver { return &dropFatalDriver{Driver: next} }),
+		WithExitFunc(func(c int) { code = c }),
+	)
+	l.Fatal(context.Background(), "fatal")
+
+	if len(d.msgs) != 0 {
+		t.Errorf("Expected fatal event to be dropped, got %v", d.msgs)
+	}
+	if code != 1 {
+		t.Errorf("Expected exit with code 1, got %d", code)
+	}
+}
@@ -2,7 +2,6 @@ package multiple
 
 import (
 	"context"
-	"os"
 	"time"
 
 	"go.uber.org/multierr"
@@ -10,54 +9,70 @@ import (
 	"github.com/Pacman29/observability/logger"
 )
 
-type drivers []logger.Driver
+type drivers struct {
+	ds []logger.Driver
+	o  *options
+}
 
 func NewMultiple(loggers ...logger.Driver) logger.Driver {
-	var ds drivers
-	ds = append(ds, loggers...)
-	return ds
+	return NewMultipleWithOptions(loggers)
+}
+
+func NewMultipleWithOptions(loggers []logger.Driver, opts ...Option) logger.Driver {
+	o := newOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &drivers{
+		ds: append([]logger.Driver(nil), loggers...),
+		o:  o,
+	}
 }
 
-func (ds drivers) Trace(ctx context.Context, h logger.EventHandler) {
-	for _, d := range ds {
+func (ds *drivers) Trace(ctx context.Context, h logger.EventHandler) {
+	for _, d := range ds.ds {
 		d.Trace(ctx, h)
 	}
 }
 
-func (ds drivers) Debug(ctx context.Context, h logger.EventHandler) {
-	for _, d := range ds {
+func (ds *drivers) Debug(ctx context.Context, h logger.EventHandler) {
+	for _, d := range ds.ds {
 		d.Debug(ctx, h)
 	}
 }
 
-func (ds drivers) Warning(ctx context.Context, h logger.EventHandler) {
-	for _, d := range ds {
+func (ds *drivers) Warning(ctx context.Context, h logger.EventHandler) {
+	for _, d := range ds.ds {
 		d.Warning(ctx, h)
 	}
 }
 
-func (ds drivers) Info(ctx context.Context, h logger.EventHandler) {
-	for _, d := range ds {
+func (ds *drivers) Info(ctx context.Context, h logger.EventHandler) {
+	for _, d := range ds.ds {
 		d.Info(ctx, h)
 	}
 }
 
-func (ds drivers) Error(ctx context.Context, h logger.EventHandler) {
-	for _, d := range ds {
+func (ds *drivers) Error(ctx context.Context, h logger.EventHandler) {
+	for _, d := range ds.ds {
 		d.Error(ctx, h)
 	}
 }
 
-func (ds drivers) Fatal(ctx context.Context, h logger.EventHandler) {
-	for _, d := range ds {
+// Fatal passes the event to Error of each driver, since Fatal of a driver may exit before the rest receive it,
+// then flushes the drivers and exits once. The event still reports FatalLevel.
+func (ds *drivers) Fatal(ctx context.Context, h logger.EventHandler) {
+	for _, d := range ds.ds {
 		d.Error(ctx, h)
 	}
-	os.Exit(1)
+	_ = ds.Flush(ds.o.flushTimeout)
+	ds.o.exit(1)
 }
 
-func (ds drivers) Flush(timeout time.Duration) error {
+func (ds *drivers) Flush(timeout time.Duration) error {
 	var errs []error
-	for _, d := range ds {
+	for _, d := range ds.ds {
 		if err := d.Flush(timeout); err != nil {
 			errs = append(errs, err)
 		}
@@ -65,8 +80,8 @@ func (ds drivers) Flush(timeout time.Duration) error {
 	return multierr.Combine(errs...)
 }
 
-func (ds drivers) Recover(err any, ctx context.Context, h logger.EventHandler) {
-	for _, d := range ds {
+func (ds *drivers) Recover(err any, ctx context.Context, h logger.EventHandler) {
+	for _, d := range ds.ds {
 		d.Recover(err, ctx, h)
 	}
 }
@@ -0,0 +1,141 @@
+package multiple
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+	"github.com/Pacman29/observability/logger/drivertest"
+)
+
+// recordDriver copies the events, so the checks of the pooled data see what it would write.
+type recordDriver struct {
+	mu      sync.Mutex
+	entries []drivertest.Entry
+}
+
+func (d *recordDriver) write(level logger.Level, h logger.EventHandler, panicValue any) {
+	e := drivertest.Entry{
+		Level:  level,
+		Msg:    h.Msg(),
+		Fields: maps.Collect(h.Fields()),
+		Tags:   maps.Collect(h.Tags()),
+	}
+	for _, v := range h.Args() {
+		e.Args = append(e.Args, v)
+	}
+	if err := h.Err(); err != nil {
+		e.Err = err.Error()
+	}
+	if req := h.Req(); req != nil {
+		e.Request = req.URL.String()
+	}
+	if panicValue != nil {
+		e.Panic = fmt.Sprint(panicValue)
+	}
+
+	d.mu.Lock()
+	d.entries = append(d.entries, e)
+	d.mu.Unlock()
+}
+
+func (d *recordDriver) Trace(ctx context.Context, h logger.EventHandler) {
+	d.write(logger.TraceLevel, h, nil)
+}
+
+func (d *recordDriver) Debug(ctx context.Context, h logger.EventHandler) {
+	d.write(logger.DebugLevel, h, nil)
+}
+
+func (d *recordDriver) Info(ctx context.Context, h logger.EventHandler) {
+	d.write(logger.InfoLevel, h, nil)
+}
+
+func (d *recordDriver) Warning(ctx context.Context, h logger.EventHandler) {
+	d.write(logger.WarningLevel, h, nil)
+}
+
+func (d *recordDriver) Error(ctx context.Context, h logger.EventHandler) {
+	d.write(logger.ErrorLevel, h, nil)
+}
+
+func (d *recordDriver) Fatal(ctx context.Context, h logger.EventHandler) {
+	d.write(logger.FatalLevel, h, nil)
+}
+
+func (d *recordDriver) Flush(timeout time.Duration) error {
+	return nil
+}
+
+func (d *recordDriver) Recover(err any, ctx context.Context, h logger.EventHandler) {
+	d.write(logger.ErrorLevel, h, err)
+}
+
+func (d *recordDriver) get() []drivertest.Entry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.entries
+}
+
+func TestDriver(t *testing.T) {
+	drivertest.Run(t, func(t *testing.T, exit func(code int)) drivertest.Harness {
+		first, second := &recordDriver{}, &recordDriver{}
+		return drivertest.Harness{
+			Driver: NewMultipleWithOptions([]logger.Driver{first, second}, WithExitFunc(exit)),
+			Entries: func() []drivertest.Entry {
+				if len(first.get()) != len(second.get()) {
+					t.Errorf("Expected the same events in all drivers, got %d and %d", len(first.get()), len(second.get()))
+				}
+				return first.get()
+			},
+		}
+	}, drivertest.WithLevelMapping(func(l logger.Level) logger.Level {
+		// Fatal is passed to Error of the drivers
+		if l == logger.FatalLevel {
+			return logger.ErrorLevel
+		}
+		return l
+	}))
+}
+
+// exitDriver exits in Fatal like zap and sentry do.
+type exitDriver struct {
+	recordDriver
+	exited  bool
+	flushed int
+}
+
+func (d *exitDriver) Fatal(ctx context.Context, h logger.EventHandler) {
+	d.exited = true
+}
+
+func (d *exitDriver) Flush(timeout time.Duration) error {
+	d.flushed++
+	return nil
+}
+
+func TestFatal(t *testing.T) {
+	first, second := &exitDriver{}, &exitDriver{}
+	var codes []int
+	ds := NewMultipleWithOptions([]logger.Driver{first, second}, WithExitFunc(func(code int) {
+		codes = append(codes, code)
+	}))
+
+	ds.Fatal(context.Background(), logger.NewEvent("fatal").SetLevel(logger.FatalLevel))
+
+	if first.exited || second.exited {
+		t.Error("Expected Fatal of the drivers not to be called")
+	}
+	for _, d := range []*exitDriver{first, second} {
+		if es := d.get(); len(es) != 1 || es[0].Msg != "fatal" || d.flushed != 1 {
+			t.Errorf("Expected the event to be written and flushed, got %v flushed %d times", es, d.flushed)
+		}
+	}
+	if len(codes) != 1 || codes[0] != 1 {
+		t.Errorf("Expected one exit, got %v", codes)
+	}
+}
@@ -0,0 +1,34 @@
+package multiple
+
+import (
+	"os"
+	"time"
+)
+
+type options struct {
+	exit         func(code int)
+	flushTimeout time.Duration
+}
+
+type Option func(o *options)
+
+func newOptions() *options {
+	return &options{
+		exit:         os.Exit,
+		flushTimeout: 5 * time.Second,
+	}
+}
+
+// WithExitFunc replaces os.Exit called by Fatal, e.g. in tests.
+func WithExitFunc(exit func(code int)) Option {
+	return func(o *options) {
+		o.exit = exit
+	}
+}
+
+// WithFlushTimeout sets the timeout of the flush before the exit of Fatal, 5 seconds by default.
+func WithFlushTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.flushTimeout = timeout
+	}
+}
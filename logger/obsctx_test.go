@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Pacman29/observability/obsctx"
+)
+
+func TestSharedTags(t *testing.T) {
+	ctx := obsctx.WithTags(context.Background(), map[string]string{"tenant": "acme", "region": "eu"})
+
+	d := &fieldsDriver{}
+	l := New(d)
+	l.Info(l.WithTag(ctx, "region", "us"), "msg")
+	if len(d.tags) != 2 || d.tags["tenant"] != "acme" || d.tags["region"] != "us" {
+		t.Errorf("Unexpected tags: %v", d.tags)
+	}
+
+	d = &fieldsDriver{}
+	l = New(d, WithSharedTags("tenant"))
+	l.Info(ctx, "msg")
+	if len(d.tags) != 1 || d.tags["tenant"] != "acme" {
+		t.Errorf("Unexpected tags: %v", d.tags)
+	}
+
+	// свои теги читаются всегда, теги метрик только разрешенные
+	ctx = obsctx.WithSourceTag(ctx, obsctx.Metrics, "route", "/users")
+	l.Info(l.WithTag(ctx, "user", "bob"), "msg")
+	if len(d.tags) != 2 || d.tags["tenant"] != "acme" || d.tags["user"] != "bob" {
+		t.Errorf("Unexpected tags: %v", d.tags)
+	}
+	if tags := ContextTags(ctx); len(tags) != 3 || tags["route"] != "/users" {
+		t.Errorf("Unexpected context tags: %v", tags)
+	}
+}
@@ -1,11 +1,23 @@
 package logger
 
-import "maps"
+import (
+	"maps"
+	"os"
+)
 
 type options struct {
 	defaultFields               map[string]any
 	defaultTags                 map[string]string
 	ctxReaders                  []CtxReader
+	level                       *AtomicLevel
+	redactor                    *Redactor
+	middlewares                 []Middleware
+	exit                        func(code int)
+	caller                      bool
+	callerStack                 bool
+	errorStack                  bool
+	errorChain                  bool
+	sharedTags                  map[string]struct{}
 	fieldsMapPoolCreateCapacity int
 	fieldsMapPoolSaveCapacity   int
 	tagsMapPoolCreateCapacity   int
@@ -21,6 +33,8 @@ func newOptions() *options {
 		defaultFields: map[string]any{},
 		defaultTags:   map[string]string{},
 		ctxReaders:    nil,
+		level:         NewAtomicLevel(TraceLevel),
+		exit:          os.Exit,
 	}
 }
 
@@ -54,6 +68,86 @@ func WithCtxReader(reader CtxReader) Option {
 	}
 }
 
+// WithAtomicLevel sets the minimum level shared with the caller, so it can be changed at runtime.
+// Events below the level are dropped before they reach the Driver.
+func WithAtomicLevel(level *AtomicLevel) Option {
+	return func(o *options) {
+		o.level = level
+	}
+}
+
+func WithMinLevel(level Level) Option {
+	return func(o *options) {
+		o.level = NewAtomicLevel(level)
+	}
+}
+
+// WithRedactor sets the redactor which hides sensitive data of each event before it is passed to the Driver.
+func WithRedactor(r *Redactor) Option {
+	return func(o *options) {
+		o.redactor = r
+	}
+}
+
+// WithMiddleware adds middlewares around the driver, see Chain for the order.
+func WithMiddleware(mws ...Middleware) Option {
+	return func(o *options) {
+		o.middlewares = append(o.middlewares, mws...)
+	}
+}
+
+// WithExitFunc replaces os.Exit which is called by Fatal after the driver.
+func WithExitFunc(f func(code int)) Option {
+	return func(o *options) {
+		o.exit = f
+	}
+}
+
+// WithCaller enables capturing of the caller location for each event, see EventHandler.Caller.
+// It costs a runtime.Callers call per event, so it is disabled by default.
+func WithCaller(enabled bool) Option {
+	return func(o *options) {
+		o.caller = enabled
+	}
+}
+
+// WithCallerStack enables capturing of the whole stack along with the caller location.
+func WithCallerStack(enabled bool) Option {
+	return func(o *options) {
+		o.callerStack = enabled
+		if enabled {
+			o.caller = true
+		}
+	}
+}
+
+// WithErrorStack enables capturing of the stack trace by WrapError, see ErrorStack.
+func WithErrorStack(enabled bool) Option {
+	return func(o *options) {
+		o.errorStack = enabled
+	}
+}
+
+// WithSharedTags limits the tags added by the metrics or obsctx which are added to the events, the tags of Logger.WithTag
+// are always added. All of them are added by default.
+func WithSharedTags(keys ...string) Option {
+	return func(o *options) {
+		if o.sharedTags == nil {
+			o.sharedTags = map[string]struct{}{}
+		}
+		for _, k := range keys {
+			o.sharedTags[k] = struct{}{}
+		}
+	}
+}
+
+// WithErrorChain enables the ErrorChainKey field with the causes of the logged error, it is disabled by default.
+func WithErrorChain(enabled bool) Option {
+	return func(o *options) {
+		o.errorChain = enabled
+	}
+}
+
 func WithFieldsMapPoolCreateCapacity(c int) Option {
 	return func(o *options) {
 		o.fieldsMapPoolCreateCapacity = c
@@ -0,0 +1,349 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+type RedactAction int
+
+const (
+	// RedactMask replaces the value with MaskedValue.
+	RedactMask RedactAction = iota
+	// RedactHash replaces the value with a short sha256 hash, so equal values can still be correlated.
+	RedactHash
+	// RedactDrop removes the value.
+	RedactDrop
+)
+
+const MaskedValue = "[REDACTED]"
+
+var DefaultRedactKeys = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"access_token",
+	"refresh_token",
+	"api_key",
+	"apikey",
+	"x-api-key",
+	"authorization",
+	"proxy-authorization",
+	"cookie",
+	"set-cookie",
+}
+
+// Redactable is implemented by values which know how to hide their sensitive data before being logged.
+type Redactable interface {
+	Redact() any
+}
+
+type patternRule struct {
+	pattern *regexp.Regexp
+	action  RedactAction
+}
+
+// typedArg returns the key and the value of the arg of a known type, ok is false for other types.
+// join builds the arg back with the redacted value.
+type typedArg func(arg any) (k string, v any, join func(v any) any, ok bool)
+
+// Redactor hides sensitive data of the event: fields, tags, args and the request.
+type Redactor struct {
+	keys      map[string]RedactAction
+	patterns  []patternRule
+	typedArgs []typedArg
+}
+
+type RedactRule func(r *Redactor)
+
+func NewRedactor(rules ...RedactRule) *Redactor {
+	r := &Redactor{
+		keys: map[string]RedactAction{},
+	}
+	for _, rule := range rules {
+		rule(r)
+	}
+	return r
+}
+
+// RedactKeys matches values by their key names case-insensitively,
+// the names are also matched against the request headers and query parameters.
+func RedactKeys(action RedactAction, keys ...string) RedactRule {
+	return func(r *Redactor) {
+		for _, k := range keys {
+			r.keys[strings.ToLower(k)] = action
+		}
+	}
+}
+
+// RedactPattern matches string values by the pattern. Mask and hash are applied to the matched parts only,
+// drop removes the whole value.
+func RedactPattern(action RedactAction, pattern *regexp.Regexp) RedactRule {
+	return func(r *Redactor) {
+		r.patterns = append(r.patterns, patternRule{pattern: pattern, action: action})
+	}
+}
+
+// RedactArgType makes RedactArgs redact the args of type T by their own keys, e.g. zap.Field of the zap driver.
+// split returns the key of the arg and its value, the value may be nil if only the key is checked.
+// join builds the arg with the redacted value, it is called only if the key matches or the value is not nil.
+// Attr and slog.Attr are known without the rule.
+func RedactArgType[T any](split func(arg T) (string, any), join func(k string, v any) T) RedactRule {
+	return func(r *Redactor) {
+		r.typedArgs = append(r.typedArgs, func(arg any) (string, any, func(v any) any, bool) {
+			t, ok := arg.(T)
+			if !ok {
+				return "", nil, nil, false
+			}
+			k, v := split(t)
+			return k, v, func(v any) any { return join(k, v) }, true
+		})
+	}
+}
+
+func hashValue(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
+
+func (r *Redactor) apply(action RedactAction, v string) string {
+	if action == RedactHash {
+		return hashValue(v)
+	}
+	return MaskedValue
+}
+
+func (r *Redactor) redactString(s string) (string, bool) {
+	for _, p := range r.patterns {
+		if !p.pattern.MatchString(s) {
+			continue
+		}
+		if p.action == RedactDrop {
+			return "", false
+		}
+		s = p.pattern.ReplaceAllStringFunc(s, func(m string) string {
+			return r.apply(p.action, m)
+		})
+	}
+	return s, true
+}
+
+// Value returns the redacted value of k, false means that the value must be dropped.
+func (r *Redactor) Value(k string, v any) (any, bool) {
+	if action, ok := r.keys[strings.ToLower(k)]; ok {
+		if action == RedactDrop {
+			return nil, false
+		}
+		if s, ok := v.(string); ok {
+			return r.apply(action, s), true
+		}
+		return MaskedValue, true
+	}
+
+	if lazy, ok := v.(*LazyValue); ok {
+		// keep the value lazy, it is redacted only when it is computed
+		return Lazy(func() any {
+			nv, _ := r.Value(k, lazy.Value())
+			return nv
+		}), true
+	}
+	if redactable, ok := v.(Redactable); ok {
+		v = redactable.Redact()
+	}
+	if s, ok := v.(string); ok {
+		return r.redactString(s)
+	}
+	return v, true
+}
+
+func (r *Redactor) RedactFields(fields map[string]any) {
+	for k, v := range fields {
+		if nv, ok := r.Value(k, v); ok {
+			fields[k] = nv
+		} else {
+			delete(fields, k)
+		}
+	}
+}
+
+func (r *Redactor) RedactTags(tags map[string]string) {
+	for k, v := range tags {
+		if nv, ok := r.Value(k, v); ok {
+			tags[k] = nv.(string)
+		} else {
+			delete(tags, k)
+		}
+	}
+}
+
+// RedactArgs redacts the args in place. The args are treated as key-value pairs,
+// so the value following a matched string key is redacted as well. Attr, slog.Attr and the types of RedactArgType
+// are redacted by their own keys.
+func (r *Redactor) RedactArgs(args []any) []any {
+	res := args[:0]
+	for i := 0; i < len(args); i++ {
+		if nv, keep, ok := r.redactTypedArg(args[i]); ok {
+			if keep {
+				res = append(res, nv)
+			}
+			continue
+		}
+		if k, ok := args[i].(string); ok && i+1 < len(args) {
+			if _, matched := r.keys[strings.ToLower(k)]; matched {
+				if nv, ok := r.Value(k, args[i+1]); ok {
+					res = append(res, k, nv)
+				}
+				i++
+				continue
+			}
+		}
+		if nv, ok := r.Value("", args[i]); ok {
+			res = append(res, nv)
+		}
+	}
+	clear(args[len(res):])
+	return res
+}
+
+func (r *Redactor) redactTypedArg(arg any) (any, bool, bool) {
+	switch a := arg.(type) {
+	case Attr:
+		na, keep := r.redactAttr(a)
+		return na, keep, true
+	case slog.Attr:
+		na, keep := r.redactSlogAttr(a)
+		return na, keep, true
+	}
+
+	for _, typed := range r.typedArgs {
+		k, v, join, ok := typed(arg)
+		if !ok {
+			continue
+		}
+		_, matched := r.keys[strings.ToLower(k)]
+		if !matched && v == nil {
+			return arg, true, true
+		}
+		nv, keep := r.Value(k, v)
+		if !keep {
+			return nil, false, true
+		}
+		return join(nv), true, true
+	}
+	return nil, false, false
+}
+
+func (r *Redactor) redactSlogAttr(a slog.Attr) (slog.Attr, bool) {
+	if _, matched := r.keys[strings.ToLower(a.Key)]; !matched {
+		switch a.Value.Kind() {
+		case slog.KindGroup:
+			attrs := make([]slog.Attr, 0, len(a.Value.Group()))
+			for _, ga := range a.Value.Group() {
+				if na, keep := r.redactSlogAttr(ga); keep {
+					attrs = append(attrs, na)
+				}
+			}
+			return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}, true
+		case slog.KindString, slog.KindAny, slog.KindLogValuer:
+		default:
+			return a, true
+		}
+	}
+
+	nv, keep := r.Value(a.Key, a.Value.Resolve().Any())
+	if !keep {
+		return slog.Attr{}, false
+	}
+	return slog.Any(a.Key, nv), true
+}
+
+// RedactAttrs redacts the attrs in place. Only the attrs of matched keys, strings and values of KindAny are checked,
+// so other typed attrs are not boxed.
+func (r *Redactor) RedactAttrs(attrs []Attr) []Attr {
+	res := attrs[:0]
+	for _, a := range attrs {
+		if na, keep := r.redactAttr(a); keep {
+			res = append(res, na)
+		}
+	}
+	clear(attrs[len(res):])
+	return res
+}
+
+func (r *Redactor) redactAttr(a Attr) (Attr, bool) {
+	_, matched := r.keys[strings.ToLower(a.Key)]
+	switch {
+	case matched:
+		nv, ok := r.Value(a.Key, a.Value())
+		if !ok {
+			return Attr{}, false
+		}
+		a = Any(a.Key, nv)
+	case a.kind == KindAny:
+		nv, ok := r.Value(a.Key, a.any)
+		if !ok {
+			return Attr{}, false
+		}
+		a.any = nv
+	case a.kind == KindString && len(r.patterns) != 0:
+		s, ok := r.redactString(a.str)
+		if !ok {
+			return Attr{}, false
+		}
+		a.str = s
+	}
+	return a, true
+}
+
+// RedactRequest returns a copy of req with redacted headers and query parameters, req itself is not modified.
+// The body of the copy is omitted, it may hold credentials the rules can't find.
+func (r *Redactor) RedactRequest(req *http.Request) *http.Request {
+	if req == nil {
+		return nil
+	}
+
+	res := req.Clone(req.Context())
+	res.Body = http.NoBody
+	res.GetBody = nil
+	res.ContentLength = 0
+
+	for k, values := range res.Header {
+		if res.Header[k] = r.redactValues(k, values); len(res.Header[k]) == 0 {
+			delete(res.Header, k)
+		}
+	}
+
+	if res.URL != nil && res.URL.RawQuery != "" {
+		query := res.URL.Query()
+		for k, values := range query {
+			if query[k] = r.redactValues(k, values); len(query[k]) == 0 {
+				delete(query, k)
+			}
+		}
+		res.URL.RawQuery = query.Encode()
+	}
+	return res
+}
+
+func (r *Redactor) redactValues(k string, values []string) []string {
+	res := values[:0]
+	for _, v := range values {
+		if nv, ok := r.Value(k, v); ok {
+			res = append(res, nv.(string))
+		}
+	}
+	return res
+}
+
+func (r *Redactor) redactHandler(h *logEventHandler) {
+	r.RedactFields(h.fields)
+	r.RedactTags(h.tags)
+	h.args = r.RedactArgs(h.args)
+	h.attrs = r.RedactAttrs(h.attrs)
+	h.req = r.RedactRequest(h.req)
+}
@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRedactor(t *testing.T) {
+	r := NewRedactor(
+		RedactKeys(RedactMask, DefaultRedactKeys...),
+		RedactKeys(RedactDrop, "ssn"),
+		RedactKeys(RedactHash, "user_id"),
+		RedactPattern(RedactMask, regexp.MustCompile(`[\w.]+@[\w.]+`)),
+	)
+
+	fields := map[string]any{"Password": "qwerty", "ssn": "123", "user_id": "42", "comment": "mail me at a.b@c.d please"}
+	r.RedactFields(fields)
+	if fields["Password"] != MaskedValue {
+		t.Errorf("Expected password to be masked, got %v", fields["Password"])
+	}
+	if _, ok := fields["ssn"]; ok {
+		t.Error("Expected ssn to be dropped")
+	}
+	if fields["user_id"] != hashValue("42") {
+		t.Errorf("Expected user_id to be hashed, got %v", fields["user_id"])
+	}
+	if fields["comment"] != "mail me at "+MaskedValue+" please" {
+		t.Errorf("Expected email to be masked, got %v", fields["comment"])
+	}
+
+	args := r.RedactArgs([]any{"token", "abc", "ssn", "123", "plain"})
+	if len(args) != 3 || args[0] != "token" || args[1] != MaskedValue || args[2] != "plain" {
+		t.Errorf("Unexpected args: %v", args)
+	}
+
+	req := httptest.NewRequest("GET", "/path?token=abc&page=1", nil)
+	req.Header.Set("Authorization", "Bearer abc")
+	req.Header.Set("Accept", "*/*")
+	redacted := r.RedactRequest(req)
+	if redacted.Header.Get("Authorization") != MaskedValue || redacted.Header.Get("Accept") != "*/*" {
+		t.Errorf("Unexpected headers: %v", redacted.Header)
+	}
+	if redacted.URL.Query().Get("token") != MaskedValue || redacted.URL.Query().Get("page") != "1" {
+		t.Errorf("Unexpected query: %v", redacted.URL.RawQuery)
+	}
+	if req.Header.Get("Authorization") != "Bearer abc" || req.URL.Query().Get("token") != "abc" {
+		t.Error("Expected original request to be unchanged")
+	}
+}
+
+func TestRedactTypedArgs(t *testing.T) {
+	type field struct {
+		key   string
+		value string
+	}
+	r := NewRedactor(
+		RedactKeys(RedactMask, "password", "token"),
+		RedactKeys(RedactDrop, "ssn"),
+		RedactArgType(func(f field) (string, any) {
+			return f.key, f.value
+		}, func(k string, v any) field {
+			return field{key: k, value: v.(string)}
+		}),
+	)
+
+	args := r.RedactArgs([]any{
+		String("password", "qwerty"),
+		slog.String("token", "abc"),
+		slog.Group("user", slog.String("password", "qwerty"), slog.Int("id", 1)),
+		field{key: "Password", value: "qwerty"},
+		field{key: "ssn", value: "123"},
+		Int("id", 1),
+	})
+	if len(args) != 5 {
+		t.Fatalf("Unexpected args: %v", args)
+	}
+	if a := args[0].(Attr); a.Value() != MaskedValue {
+		t.Errorf("Expected Attr to be masked, got %v", a)
+	}
+	if a := args[1].(slog.Attr); a.Value.String() != MaskedValue {
+		t.Errorf("Expected slog.Attr to be masked, got %v", a)
+	}
+	if a := args[2].(slog.Attr); a.Value.Group()[0].Value.String() != MaskedValue || a.Value.Group()[1].Value.Int64() != 1 {
+		t.Errorf("Expected the group to be redacted, got %v", a)
+	}
+	if f := args[3].(field); f.value != MaskedValue {
+		t.Errorf("Expected the typed arg to be masked, got %v", f)
+	}
+	if a := args[4].(Attr); a.Int64() != 1 {
+		t.Errorf("Expected Attr to be kept, got %v", a)
+	}
+}
+
+func TestRedactRequestBody(t *testing.T) {
+	r := NewRedactor(RedactKeys(RedactMask, "password"))
+
+	req := httptest.NewRequest("POST", "/login", strings.NewReader(`{"password":"qwerty"}`))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(`{"password":"qwerty"}`)), nil
+	}
+	redacted := r.RedactRequest(req)
+	if body, _ := io.ReadAll(redacted.Body); len(body) != 0 || redacted.GetBody != nil {
+		t.Errorf("Expected the body to be omitted, got %q", body)
+	}
+	if body, _ := io.ReadAll(req.Body); string(body) != `{"password":"qwerty"}` {
+		t.Errorf("Expected the original body to be kept, got %q", body)
+	}
+}
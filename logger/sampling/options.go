@@ -0,0 +1,40 @@
+package sampling
+
+import "time"
+
+type options struct {
+	tick       time.Duration
+	first      uint64
+	thereafter uint64
+}
+
+type Option func(o *options)
+
+func newOptions() *options {
+	return &options{
+		tick:       time.Second,
+		first:      100,
+		thereafter: 100,
+	}
+}
+
+func WithTick(d time.Duration) Option {
+	return func(o *options) {
+		o.tick = d
+	}
+}
+
+// WithFirst sets how many events with the same level and message are passed per tick.
+func WithFirst(n uint64) Option {
+	return func(o *options) {
+		o.first = n
+	}
+}
+
+// WithThereafter sets that only every Mth event is passed once the first ones were passed in the tick.
+// Zero drops all of them.
+func WithThereafter(m uint64) Option {
+	return func(o *options) {
+		o.thereafter = m
+	}
+}
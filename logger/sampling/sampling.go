@@ -0,0 +1,162 @@
+package sampling
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+const DroppedMessage = "sampling: events were dropped"
+
+type key struct {
+	level logger.Level
+	msg   string
+}
+
+type counter struct {
+	resetAt time.Time
+	n       uint64
+	dropped uint64
+}
+
+type driver struct {
+	d logger.Driver
+	o *options
+
+	mu       sync.Mutex
+	counters map[key]*counter
+	// sweepAt is the time of the next eviction of the expired counters
+	sweepAt time.Time
+}
+
+// NewSamplingDriver passes the first N events for each level and message per tick and only every Mth after that.
+// Counts of the dropped events are written to d once the tick of the message is over and on Flush.
+// Fatal and Recover are never sampled.
+func NewSamplingDriver(d logger.Driver, opts ...Option) logger.Driver {
+	o := newOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &driver{
+		d:        d,
+		o:        o,
+		counters: map[key]*counter{},
+	}
+}
+
+func newDroppedEvent(k key, dropped uint64) *logger.Event {
+	return logger.NewEvent(DroppedMessage).
+		SetLevel(logger.WarningLevel).
+		SetField("sampled_message", k.msg).
+		SetField("sampled_level", k.level.String()).
+		SetField("dropped", dropped)
+}
+
+// collect returns the dropped counts of the expired counters, or of all of them if all is set,
+// and removes the expired counters. It must be called under the lock.
+func (d *driver) collect(now time.Time, all bool) []*logger.Event {
+	var events []*logger.Event
+	for k, c := range d.counters {
+		expired := !now.Before(c.resetAt)
+		if c.dropped != 0 && (expired || all) {
+			events = append(events, newDroppedEvent(k, c.dropped))
+			c.dropped = 0
+		}
+		if expired {
+			delete(d.counters, k)
+		}
+	}
+	return events
+}
+
+func (d *driver) sample(level logger.Level, msg string) (bool, []*logger.Event) {
+	now := time.Now()
+	k := key{level: level, msg: msg}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// the expired counters are evicted once per tick, otherwise the formatted messages would grow the map without bound
+	var events []*logger.Event
+	if !now.Before(d.sweepAt) {
+		events = d.collect(now, false)
+		d.sweepAt = now.Add(d.o.tick)
+	}
+
+	c, ok := d.counters[k]
+	if !ok {
+		c = &counter{}
+		d.counters[k] = c
+	}
+	if !now.Before(c.resetAt) {
+		if c.dropped != 0 {
+			events = append(events, newDroppedEvent(k, c.dropped))
+			c.dropped = 0
+		}
+		c.n = 0
+		c.resetAt = now.Add(d.o.tick)
+	}
+	c.n++
+
+	if c.n <= d.o.first || (d.o.thereafter > 0 && (c.n-d.o.first)%d.o.thereafter == 0) {
+		return true, events
+	}
+	c.dropped++
+	return false, events
+}
+
+func (d *driver) writeDropped(events []*logger.Event) {
+	ctx := context.Background()
+	for _, e := range events {
+		d.d.Warning(ctx, e)
+	}
+}
+
+func (d *driver) write(ctx context.Context, level logger.Level, h logger.EventHandler) {
+	ok, dropped := d.sample(level, h.Msg())
+	d.writeDropped(dropped)
+	if ok {
+		logger.Dispatch(ctx, d.d, level, h)
+	}
+}
+
+func (d *driver) Trace(ctx context.Context, h logger.EventHandler) {
+	d.write(ctx, logger.TraceLevel, h)
+}
+
+func (d *driver) Debug(ctx context.Context, h logger.EventHandler) {
+	d.write(ctx, logger.DebugLevel, h)
+}
+
+func (d *driver) Info(ctx context.Context, h logger.EventHandler) {
+	d.write(ctx, logger.InfoLevel, h)
+}
+
+func (d *driver) Warning(ctx context.Context, h logger.EventHandler) {
+	d.write(ctx, logger.WarningLevel, h)
+}
+
+func (d *driver) Error(ctx context.Context, h logger.EventHandler) {
+	d.write(ctx, logger.ErrorLevel, h)
+}
+
+func (d *driver) Fatal(ctx context.Context, h logger.EventHandler) {
+	d.d.Fatal(ctx, h)
+}
+
+func (d *driver) Recover(err any, ctx context.Context, h logger.EventHandler) {
+	d.d.Recover(err, ctx, h)
+}
+
+// Flush writes the counts of the dropped events and flushes the wrapped driver.
+func (d *driver) Flush(timeout time.Duration) error {
+	d.mu.Lock()
+	events := d.collect(time.Now(), true)
+	d.mu.Unlock()
+
+	d.writeDropped(events)
+	return d.d.Flush(timeout)
+}
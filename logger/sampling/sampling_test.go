@@ -0,0 +1,107 @@
+package sampling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+type recordDriver struct {
+	events []logger.EventHandler
+}
+
+func (d *recordDriver) add(h logger.EventHandler) {
+	d.events = append(d.events, logger.Snapshot(h))
+}
+
+func (d *recordDriver) Trace(ctx context.Context, h logger.EventHandler)   { d.add(h) }
+func (d *recordDriver) Debug(ctx context.Context, h logger.EventHandler)   { d.add(h) }
+func (d *recordDriver) Warning(ctx context.Context, h logger.EventHandler) { d.add(h) }
+func (d *recordDriver) Info(ctx context.Context, h logger.EventHandler)    { d.add(h) }
+func (d *recordDriver) Error(ctx context.Context, h logger.EventHandler)   { d.add(h) }
+func (d *recordDriver) Fatal(ctx context.Context, h logger.EventHandler)   { d.add(h) }
+func (d *recordDriver) Flush(timeout time.Duration) error                  { return nil }
+func (d *recordDriver) Recover(err any, ctx context.Context, h logger.EventHandler) {
+	d.add(h)
+}
+
+func TestSampling(t *testing.T) {
+	d := &recordDriver{}
+	l := logger.New(NewSamplingDriver(d, WithTick(time.Hour), WithFirst(2), WithThereafter(3)))
+
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		l.Warning(ctx, "hot loop")
+	}
+	l.Info(ctx, "hot loop")
+
+	// 1, 2 are the first ones, then 5 and 8 are every 3rd, plus the info event
+	if len(d.events) != 5 {
+		t.Fatalf("Expected 5 events, got %d", len(d.events))
+	}
+
+	l.Flush(time.Second)
+	if len(d.events) != 6 {
+		t.Fatalf("Expected dropped summary to be written, got %d events", len(d.events))
+	}
+
+	summary := d.events[5]
+	fields := map[string]any{}
+	for k, v := range summary.Fields() {
+		fields[k] = v
+	}
+	if summary.Msg() != DroppedMessage || fields["dropped"] != uint64(6) || fields["sampled_level"] != "warning" {
+		t.Errorf("Unexpected summary: %s %v", summary.Msg(), fields)
+	}
+
+	l.Flush(time.Second)
+	if len(d.events) != 6 {
+		t.Errorf("Expected summary to be written once, got %d events", len(d.events))
+	}
+}
+
+func TestDroppedOnTick(t *testing.T) {
+	d := &recordDriver{}
+	l := logger.New(NewSamplingDriver(d, WithTick(10*time.Millisecond), WithFirst(1), WithThereafter(0)))
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		l.Warning(ctx, "hot loop")
+	}
+	time.Sleep(20 * time.Millisecond)
+	l.Info(ctx, "next tick")
+
+	// the first event, the summary of the expired tick and the new event, without Flush
+	if len(d.events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(d.events))
+	}
+	summary := d.events[1]
+	fields := map[string]any{}
+	for k, v := range summary.Fields() {
+		fields[k] = v
+	}
+	if summary.Msg() != DroppedMessage || fields["dropped"] != uint64(2) || fields["sampled_message"] != "hot loop" {
+		t.Errorf("Unexpected summary: %s %v", summary.Msg(), fields)
+	}
+}
+
+func TestExpiredCountersEvicted(t *testing.T) {
+	d := NewSamplingDriver(&recordDriver{}, WithTick(10*time.Millisecond)).(*driver)
+	l := logger.New(d)
+
+	ctx := context.Background()
+	for i := 0; i < 1000; i++ {
+		l.Errorf(ctx, "request %d failed", i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	l.Errorf(ctx, "request %d failed", 1000)
+
+	d.mu.Lock()
+	n := len(d.counters)
+	d.mu.Unlock()
+	if n != 1 {
+		t.Errorf("Expected the expired counters to be evicted, got %d counters", n)
+	}
+}
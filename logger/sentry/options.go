@@ -2,6 +2,7 @@ package sentry
 
 import (
 	"context"
+	"os"
 
 	"github.com/getsentry/sentry-go"
 )
@@ -16,6 +17,7 @@ type options struct {
 	fieldsPoolCapCreate      int
 	argsPoolCapSave          int
 	argsPoolCapCreate        int
+	exit                     func(code int)
 }
 
 type Option func(o *options)
@@ -31,6 +33,7 @@ func newOptions() *options {
 		fieldsPoolCapCreate:      10,
 		argsPoolCapSave:          20,
 		argsPoolCapCreate:        10,
+		exit:                     os.Exit,
 	}
 }
 
@@ -87,3 +90,10 @@ func WithArgsPoolCapSave(n int) Option {
 		o.argsPoolCapSave = n
 	}
 }
+
+// WithExitFunc replaces os.Exit called by Fatal, e.g. in tests.
+func WithExitFunc(exit func(code int)) Option {
+	return func(o *options) {
+		o.exit = exit
+	}
+}
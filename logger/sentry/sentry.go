@@ -4,7 +4,8 @@ import (
 	"context"
 	"errors"
 	"maps"
-	"os"
+	"runtime"
+	"slices"
 	"time"
 
 	"github.com/getsentry/sentry-go"
@@ -38,6 +39,7 @@ func NewSentryDriver(client *sentry.Client, opts ...Option) logger.Driver {
 
 func (d *driver) newScopeFromCtx(ctx context.Context, h logger.EventHandler) *sentry.Scope {
 	scope := sentry.NewScope()
+	scope.SetLevel(sentryLevel(h.Level()))
 
 	if d.options.sentryUserResolver != nil {
 		scope.SetUser(d.options.sentryUserResolver(ctx))
@@ -47,6 +49,14 @@ func (d *driver) newScopeFromCtx(ctx context.Context, h logger.EventHandler) *se
 		scope.SetRequest(req)
 	}
 
+	if c := h.Caller(); c != nil {
+		scope.SetContext("caller", sentry.Context{
+			"function": c.Function,
+			"file":     c.File,
+			"line":     c.Line,
+		})
+	}
+
 	tagsMap := d.tagsPool.Get()
 	defer func() {
 		d.tagsPool.Save(tagsMap)
@@ -67,7 +77,7 @@ func (d *driver) newScopeFromCtx(ctx context.Context, h logger.EventHandler) *se
 	}()
 
 	for k, v := range h.Fields() {
-		fieldsMap[k] = v
+		fieldsMap[k] = logger.Resolve(v)
 	}
 	if d.options.additionalFieldsResolver != nil {
 		maps.Copy(fieldsMap, d.options.additionalFieldsResolver(ctx))
@@ -79,14 +89,44 @@ func (d *driver) newScopeFromCtx(ctx context.Context, h logger.EventHandler) *se
 	}()
 
 	for _, v := range h.Args() {
-		args = append(args, v)
+		args = append(args, logger.Resolve(v))
 	}
-	fieldsMap["__additional_args"] = args
+	// the extras keep the slice, so it must not be returned to the pool
+	fieldsMap["__additional_args"] = slices.Clone(args)
 
 	scope.SetExtras(fieldsMap)
 	return scope
 }
 
+// newStacktrace converts frames to the sentry format, where the most recent call is the last one.
+func newStacktrace(frames []logger.Frame) *sentry.Stacktrace {
+	st := &sentry.Stacktrace{Frames: make([]sentry.Frame, 0, len(frames))}
+	for _, f := range slices.Backward(frames) {
+		st.Frames = append(st.Frames, sentry.NewFrame(runtime.Frame{
+			PC:       f.PC,
+			Function: f.Function,
+			File:     f.File,
+			Line:     f.Line,
+		}))
+	}
+	return st
+}
+
+func sentryLevel(l logger.Level) sentry.Level {
+	switch l {
+	case logger.TraceLevel, logger.DebugLevel:
+		return sentry.LevelDebug
+	case logger.InfoLevel:
+		return sentry.LevelInfo
+	case logger.WarningLevel:
+		return sentry.LevelWarning
+	case logger.FatalLevel:
+		return sentry.LevelFatal
+	default:
+		return sentry.LevelError
+	}
+}
+
 func (d *driver) Trace(ctx context.Context, h logger.EventHandler) {}
 
 func (d *driver) Debug(ctx context.Context, h logger.EventHandler) {}
@@ -95,13 +135,28 @@ func (d *driver) Warning(ctx context.Context, h logger.EventHandler) {}
 
 func (d *driver) Info(ctx context.Context, h logger.EventHandler) {}
 
+func (d *driver) captureException(ctx context.Context, h logger.EventHandler) {
+	if h.Err() == nil {
+		d.c.CaptureEvent(d.c.EventFromMessage(h.Msg(), sentryLevel(h.Level())), nil, d.newScopeFromCtx(ctx, h))
+		return
+	}
+
+	event := d.c.EventFromException(h.Err(), sentryLevel(h.Level()))
+	event.Message = h.Msg()
+	if frames := logger.ErrorStack(h.Err()); len(frames) != 0 && len(event.Exception) != 0 {
+		// the most recent error is the last one, by default its stack trace points to this driver
+		event.Exception[len(event.Exception)-1].Stacktrace = newStacktrace(frames)
+	}
+	d.c.CaptureEvent(event, nil, d.newScopeFromCtx(ctx, h))
+}
+
 func (d *driver) Error(ctx context.Context, h logger.EventHandler) {
-	d.c.CaptureException(h.Err(), nil, d.newScopeFromCtx(ctx, h))
+	d.captureException(ctx, h)
 }
 
 func (d *driver) Fatal(ctx context.Context, h logger.EventHandler) {
-	d.c.CaptureException(h.Err(), nil, d.newScopeFromCtx(ctx, h))
-	os.Exit(1)
+	d.captureException(ctx, h)
+	d.options.exit(1)
 }
 
 func (d *driver) Flush(timeout time.Duration) error {
@@ -112,7 +167,9 @@ func (d *driver) Flush(timeout time.Duration) error {
 }
 
 func (d *driver) Recover(err any, ctx context.Context, h logger.EventHandler) {
-	d.c.Recover(err, nil, d.newScopeFromCtx(ctx, h))
+	scope := d.newScopeFromCtx(ctx, h)
+	scope.SetLevel(sentry.LevelFatal)
+	d.c.Recover(err, nil, scope)
 	go func() {
 		d.c.Flush(time.Second * 5)
 	}()
@@ -0,0 +1,82 @@
+package sentry
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+
+	"github.com/Pacman29/observability/logger"
+	"github.com/Pacman29/observability/logger/drivertest"
+)
+
+var levels = map[sentry.Level]logger.Level{
+	sentry.LevelDebug:   logger.DebugLevel,
+	sentry.LevelInfo:    logger.InfoLevel,
+	sentry.LevelWarning: logger.WarningLevel,
+	sentry.LevelError:   logger.ErrorLevel,
+	sentry.LevelFatal:   logger.FatalLevel,
+}
+
+// recordTransport keeps the events which would be sent to sentry.
+type recordTransport struct {
+	mu      sync.Mutex
+	entries []drivertest.Entry
+}
+
+func (t *recordTransport) Flush(time.Duration) bool {
+	return true
+}
+
+func (t *recordTransport) Configure(sentry.ClientOptions) {}
+
+func (t *recordTransport) SendEvent(event *sentry.Event) {
+	e := drivertest.Entry{
+		Level:  levels[event.Level],
+		Msg:    event.Message,
+		Fields: map[string]any{},
+		Tags:   event.Tags,
+	}
+	for k, v := range event.Extra {
+		if k == "__additional_args" {
+			e.Args, _ = v.([]any)
+			continue
+		}
+		e.Fields[k] = v
+	}
+	if len(event.Exception) != 0 {
+		e.Err = event.Exception[len(event.Exception)-1].Value
+	}
+	if event.Request != nil {
+		e.Request = event.Request.URL
+	}
+
+	t.mu.Lock()
+	t.entries = append(t.entries, e)
+	t.mu.Unlock()
+}
+
+func (t *recordTransport) Close() {}
+
+func TestDriver(t *testing.T) {
+	drivertest.Run(t, func(t *testing.T, exit func(code int)) drivertest.Harness {
+		transport := &recordTransport{}
+		client, err := sentry.NewClient(sentry.ClientOptions{
+			Dsn:       "https://public@example.com/1",
+			Transport: transport,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return drivertest.Harness{
+			Driver: NewSentryDriver(client, WithExitFunc(exit)),
+			Entries: func() []drivertest.Entry {
+				transport.mu.Lock()
+				defer transport.mu.Unlock()
+				return transport.entries
+			},
+		}
+	}, drivertest.WithLevels(logger.ErrorLevel, logger.FatalLevel))
+}
@@ -2,12 +2,14 @@ package slog
 
 import (
 	"context"
+	"os"
 )
 
 type options struct {
 	createCap       int
 	saveCap         int
 	ctxArgsResolver func(ctx context.Context) []any
+	exit            func(code int)
 }
 
 type Option func(o *options)
@@ -17,6 +19,7 @@ func newOptions() *options {
 		createCap:       10,
 		saveCap:         20,
 		ctxArgsResolver: nil,
+		exit:            os.Exit,
 	}
 }
 
@@ -37,3 +40,10 @@ func WithCtxArgsResolver(f func(ctx context.Context) []any) Option {
 		o.ctxArgsResolver = f
 	}
 }
+
+// WithExitFunc replaces os.Exit called by Fatal, e.g. in tests.
+func WithExitFunc(exit func(code int)) Option {
+	return func(o *options) {
+		o.exit = exit
+	}
+}
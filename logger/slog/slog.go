@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"os"
 	"runtime/debug"
 	"time"
 
@@ -34,13 +33,30 @@ func NewSlogDriver(logger *slog.Logger, opts ...Option) logger.Driver {
 }
 
 func (d *driver) writeLog(ctx context.Context, level slog.Level, h logger.EventHandler) {
+	d.write(ctx, level, h.Msg(), h)
+}
+
+func (d *driver) write(ctx context.Context, level slog.Level, msg string, h logger.EventHandler, extra ...any) {
+	if !d.l.Enabled(ctx, level) {
+		return
+	}
+
 	args := d.pool.Get()
 	defer func() {
 		d.pool.Save(args)
 	}()
-	var msg string
-	msg, args = d.toSlogArgs(ctx, args, h)
-	d.l.Log(ctx, level, msg, args...)
+	args = d.toSlogArgs(ctx, args, h)
+	args = append(args, extra...)
+
+	// slog.Logger would take the location of this driver as the source, so the record is built
+	// with the location of the logger call instead
+	var pc uintptr
+	if c := h.Caller(); c != nil {
+		pc = c.PC
+	}
+	r := slog.NewRecord(h.Time(), level, msg, pc)
+	r.Add(args...)
+	_ = d.l.Handler().Handle(ctx, r)
 }
 
 func (d *driver) Trace(ctx context.Context, h logger.EventHandler) {
@@ -64,46 +80,79 @@ func (d *driver) Error(ctx context.Context, h logger.EventHandler) {
 }
 
 func (d *driver) Recover(err any, ctx context.Context, h logger.EventHandler) {
-	args := d.pool.Get()
-	defer func() {
-		d.pool.Save(args)
-	}()
-	_, args = d.toSlogArgs(ctx, args, h)
-	args = append(args, slog.Any("stack", debug.Stack()))
-	d.l.Error(fmt.Sprintf("Panic: %v", err), args...)
+	d.write(ctx, slog.LevelError, fmt.Sprintf("Panic: %v", err), h, slog.Any("stack", debug.Stack()))
 }
 
 func (d *driver) Fatal(ctx context.Context, h logger.EventHandler) {
 	d.writeLog(ctx, slog.LevelError, h)
-	os.Exit(1)
+	d.options.exit(1)
 }
 
 func (d *driver) Flush(timeout time.Duration) error {
 	return nil
 }
 
-func (d *driver) toSlogArgs(ctx context.Context, args []any, h logger.EventHandler) (string, []any) {
+func (d *driver) toSlogArgs(ctx context.Context, args []any, h logger.EventHandler) []any {
 	for k, v := range h.Tags() {
 		args = append(args, slog.String(k, v))
 	}
-	for k, v := range h.Fields() {
-		args = append(args, slog.Any(k, v))
+	for a := range h.Attrs() {
+		args = append(args, attrToSlog(a))
 	}
 	if err := h.Err(); err != nil {
 		args = append(args, slog.Any("error", err))
+		if frames := logger.ErrorStack(err); len(frames) != 0 {
+			args = append(args, slog.Any("stacktrace", frames))
+		}
 	}
 	for _, v := range h.Args() {
-		args = append(args, v)
+		args = append(args, toSlogArg(v))
 	}
 	if req := h.Req(); req != nil {
 		if reqString, err := http2curl.GetCurlCommand(req); err != nil {
-			d.l.Warn("can't convert request to curl", err)
+			d.l.Warn("can't convert request to curl", slog.Any("error", err))
 		} else {
 			args = append(args, slog.String("request", reqString.String()))
 		}
 	}
 	if d.options.ctxArgsResolver != nil {
-		args = append(args, d.options.ctxArgsResolver(ctx)...)
+		for _, v := range d.options.ctxArgsResolver(ctx) {
+			args = append(args, toSlogArg(v))
+		}
+	}
+	return args
+}
+
+func toSlogArg(v any) any {
+	if a, ok := v.(logger.Attr); ok {
+		return attrToSlog(a)
+	}
+	return logger.Resolve(v)
+}
+
+// attrToSlog converts a without reflection, only the values of KindAny go through slog.Any.
+func attrToSlog(a logger.Attr) slog.Attr {
+	switch a.Kind() {
+	case logger.KindString:
+		return slog.String(a.Key, a.String())
+	case logger.KindInt64:
+		return slog.Int64(a.Key, a.Int64())
+	case logger.KindUint64:
+		return slog.Uint64(a.Key, a.Uint64())
+	case logger.KindFloat64:
+		return slog.Float64(a.Key, a.Float64())
+	case logger.KindBool:
+		return slog.Bool(a.Key, a.Bool())
+	case logger.KindDuration:
+		return slog.Duration(a.Key, a.Duration())
+	case logger.KindTime:
+		return slog.Time(a.Key, a.Time())
+	case logger.KindStringer:
+		if s := a.Stringer(); s != nil {
+			return slog.String(a.Key, s.String())
+		}
+		return slog.Any(a.Key, nil)
+	default:
+		return slog.Any(a.Key, logger.Resolve(a.Any()))
 	}
-	return h.Msg(), args
 }
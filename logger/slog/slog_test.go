@@ -0,0 +1,83 @@
+package slog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/Pacman29/observability/logger"
+	"github.com/Pacman29/observability/logger/drivertest"
+)
+
+var levels = map[slog.Level]logger.Level{
+	slog.LevelDebug: logger.DebugLevel,
+	slog.LevelInfo:  logger.InfoLevel,
+	slog.LevelWarn:  logger.WarningLevel,
+	slog.LevelError: logger.ErrorLevel,
+}
+
+// recordHandler keeps the handled records with the resolved attrs.
+type recordHandler struct {
+	mu      sync.Mutex
+	entries []drivertest.Entry
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	e := drivertest.Entry{
+		Level:  levels[r.Level],
+		Msg:    r.Message,
+		Fields: map[string]any{},
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		v := a.Value.Resolve().Any()
+		e.Fields[a.Key] = v
+		switch a.Key {
+		case "error":
+			e.Err = fmt.Sprint(v)
+		case "request":
+			e.Request = fmt.Sprint(v)
+		}
+		return true
+	})
+
+	h.mu.Lock()
+	h.entries = append(h.entries, e)
+	h.mu.Unlock()
+	return nil
+}
+
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler {
+	return h
+}
+
+func (h *recordHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+func TestDriver(t *testing.T) {
+	drivertest.Run(t, func(t *testing.T, exit func(code int)) drivertest.Harness {
+		h := &recordHandler{}
+		return drivertest.Harness{
+			Driver: NewSlogDriver(slog.New(h), WithExitFunc(exit)),
+			Entries: func() []drivertest.Entry {
+				h.mu.Lock()
+				defer h.mu.Unlock()
+				return h.entries
+			},
+		}
+	}, drivertest.WithLevelMapping(func(l logger.Level) logger.Level {
+		switch l {
+		case logger.TraceLevel:
+			return logger.DebugLevel
+		case logger.FatalLevel:
+			return logger.ErrorLevel
+		}
+		return l
+	}))
+}
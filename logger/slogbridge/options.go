@@ -0,0 +1,36 @@
+package slogbridge
+
+import (
+	"log/slog"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+type options struct {
+	level    slog.Leveler
+	redactor *logger.Redactor
+}
+
+type Option func(o *options)
+
+func newOptions() *options {
+	return &options{
+		level:    nil,
+		redactor: nil,
+	}
+}
+
+// WithLevel sets the minimal slog level which is passed to the driver, all records are passed by default.
+func WithLevel(l slog.Leveler) Option {
+	return func(o *options) {
+		o.level = l
+	}
+}
+
+// WithRedactor redacts the events before they are passed to the driver.
+// Events written through the Logger are redacted by it, while records of slog never reach the Logger.
+func WithRedactor(r *logger.Redactor) Option {
+	return func(o *options) {
+		o.redactor = r
+	}
+}
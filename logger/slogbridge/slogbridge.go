@@ -0,0 +1,162 @@
+package slogbridge
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+type handler struct {
+	d       logger.Driver
+	options *options
+
+	// fields are the attrs added by WithAttrs, their keys already include the groups
+	fields map[string]any
+	err    error
+	prefix string
+}
+
+// NewHandler returns slog.Handler which passes the records to d, so the third-party code which logs
+// through slog.Default() gets the tags and fields of the context. Attrs become the fields of the event,
+// groups are added to their keys separated by dots. An error attr with the "error" or "err" key becomes the error of the event.
+func NewHandler(d logger.Driver, opts ...Option) slog.Handler {
+	o := newOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &handler{
+		d:       d,
+		options: o,
+		fields:  map[string]any{},
+	}
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.options.level == nil || level >= h.options.level.Level()
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	e := logger.NewEvent(r.Message).
+		SetLevel(level(r.Level)).
+		SetTime(r.Time).
+		SetErr(logger.ContextError(ctx)).
+		SetReq(logger.ContextRequest(ctx))
+	if r.PC != 0 {
+		if frames := logger.Frames([]uintptr{r.PC}); len(frames) != 0 {
+			e.SetCaller(&logger.Caller{Frame: frames[0]})
+		}
+	}
+
+	fields := logger.ContextFields(ctx)
+	if fields == nil {
+		fields = make(map[string]any, len(h.fields)+r.NumAttrs())
+	}
+	maps.Copy(fields, h.fields)
+	err := h.err
+	r.Attrs(func(a slog.Attr) bool {
+		if attrErr := addAttr(fields, h.prefix, a); attrErr != nil {
+			err = attrErr
+		}
+		return true
+	})
+	if err != nil {
+		e.SetErr(err)
+	}
+	tags := logger.ContextTags(ctx)
+
+	if h.options.redactor != nil {
+		h.options.redactor.RedactFields(fields)
+		h.options.redactor.RedactTags(tags)
+		e.SetReq(h.options.redactor.RedactRequest(e.Req()))
+	}
+	for k, v := range fields {
+		e.SetField(k, v)
+	}
+	for k, v := range tags {
+		e.SetTag(k, v)
+	}
+
+	logger.Dispatch(ctx, h.d, e.Level(), e)
+	return nil
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	nh := h.clone()
+	for _, a := range attrs {
+		if err := addAttr(nh.fields, nh.prefix, a); err != nil {
+			nh.err = err
+		}
+	}
+	return nh
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	nh := h.clone()
+	nh.prefix = h.prefix + name + "."
+	return nh
+}
+
+func (h *handler) clone() *handler {
+	nh := *h
+	nh.fields = maps.Clone(h.fields)
+	return &nh
+}
+
+// addAttr adds a to fields following the rules of slog.Handler, the error is returned instead of being added.
+func addAttr(fields map[string]any, prefix string, a slog.Attr) error {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return nil
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		attrs := a.Value.Group()
+		if len(attrs) == 0 {
+			return nil
+		}
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		var err error
+		for _, ga := range attrs {
+			if e := addAttr(fields, prefix, ga); e != nil {
+				err = e
+			}
+		}
+		return err
+	}
+
+	if err, ok := a.Value.Any().(error); ok && prefix == "" && (a.Key == "error" || a.Key == "err") {
+		return err
+	}
+	fields[prefix+a.Key] = a.Value.Any()
+	return nil
+}
+
+// level maps slog levels to the logger ones, the levels between the named slog levels are rounded down.
+// Fatal is never used: slog has no such level and the driver must not exit the process.
+func level(l slog.Level) logger.Level {
+	switch {
+	case l < slog.LevelDebug:
+		return logger.TraceLevel
+	case l < slog.LevelInfo:
+		return logger.DebugLevel
+	case l < slog.LevelWarn:
+		return logger.InfoLevel
+	case l < slog.LevelError:
+		return logger.WarningLevel
+	default:
+		return logger.ErrorLevel
+	}
+}
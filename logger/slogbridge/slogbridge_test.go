@@ -0,0 +1,97 @@
+package slogbridge
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+type recordDriver struct {
+	events []*logger.Event
+}
+
+func (d *recordDriver) write(h logger.EventHandler) {
+	d.events = append(d.events, logger.Snapshot(h))
+}
+
+func (d *recordDriver) Trace(ctx context.Context, h logger.EventHandler)   { d.write(h) }
+func (d *recordDriver) Debug(ctx context.Context, h logger.EventHandler)   { d.write(h) }
+func (d *recordDriver) Warning(ctx context.Context, h logger.EventHandler) { d.write(h) }
+func (d *recordDriver) Info(ctx context.Context, h logger.EventHandler)    { d.write(h) }
+func (d *recordDriver) Error(ctx context.Context, h logger.EventHandler)   { d.write(h) }
+func (d *recordDriver) Fatal(ctx context.Context, h logger.EventHandler)   { d.write(h) }
+func (d *recordDriver) Flush(timeout time.Duration) error                  { return nil }
+func (d *recordDriver) Recover(err any, ctx context.Context, h logger.EventHandler) {
+	d.write(h)
+}
+
+func TestHandler(t *testing.T) {
+	d := &recordDriver{}
+	l := logger.New(d)
+	ctx := l.WithTag(context.Background(), "request_id", "42")
+	ctx = l.WithField(ctx, "user", "bob")
+
+	sl := slog.New(NewHandler(d)).With("component", "db").WithGroup("query")
+	err := errors.New("timeout")
+	sl.WarnContext(ctx, "slow query", "table", "users", slog.Group("stats", "rows", 10), "error", err)
+
+	if len(d.events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(d.events))
+	}
+	e := d.events[0]
+	if e.Level() != logger.WarningLevel || e.Msg() != "slow query" {
+		t.Errorf("Unexpected event: %v %q", e.Level(), e.Msg())
+	}
+
+	fields := map[string]any{}
+	for k, v := range e.Fields() {
+		fields[k] = v
+	}
+	expected := map[string]any{
+		"user":             "bob",
+		"component":        "db",
+		"query.table":      "users",
+		"query.stats.rows": int64(10),
+		"query.error":      err,
+	}
+	for k, v := range expected {
+		if fields[k] != v {
+			t.Errorf("Expected field %s=%v, got %v", k, v, fields[k])
+		}
+	}
+	for k, v := range e.Tags() {
+		if k != "request_id" || v != "42" {
+			t.Errorf("Unexpected tag %s=%s", k, v)
+		}
+	}
+	if c := e.Caller(); c == nil || !strings.HasSuffix(c.File, "slogbridge_test.go") {
+		t.Errorf("Expected caller in the test, got %v", c)
+	}
+}
+
+func TestHandlerError(t *testing.T) {
+	d := &recordDriver{}
+	h := NewHandler(d, WithLevel(slog.LevelInfo), WithRedactor(logger.NewRedactor(logger.RedactKeys(logger.RedactMask, "password"))))
+
+	err := errors.New("failed")
+	slog.New(h).Debug("skipped")
+	slog.New(h).Error("login", "err", err, "password", "qwerty")
+
+	if len(d.events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(d.events))
+	}
+	e := d.events[0]
+	if e.Err() != err || e.Level() != logger.ErrorLevel {
+		t.Errorf("Unexpected event: %v %v", e.Level(), e.Err())
+	}
+	for k, v := range e.Fields() {
+		if k != "password" || v != logger.MaskedValue {
+			t.Errorf("Unexpected field %s=%v", k, v)
+		}
+	}
+}
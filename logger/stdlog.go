@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"context"
+	"log"
+	"strings"
+)
+
+const (
+	StdLogSourceTag   = "source"
+	StdLogSourceValue = "stdlog"
+)
+
+type stdLoggerOptions struct {
+	ctx         context.Context
+	parseLevels bool
+}
+
+type StdLoggerOption func(o *stdLoggerOptions)
+
+func newStdLoggerOptions() *stdLoggerOptions {
+	return &stdLoggerOptions{
+		ctx:         context.Background(),
+		parseLevels: true,
+	}
+}
+
+// WithStdContext sets the context whose fields and tags are added to every line.
+func WithStdContext(ctx context.Context) StdLoggerOption {
+	return func(o *stdLoggerOptions) {
+		o.ctx = ctx
+	}
+}
+
+// WithStdLevelPrefix enables the level prefixes of the lines such as "[WARN]", enabled by default.
+// Lines without the prefix are logged with the level of NewStdLogger.
+func WithStdLevelPrefix(enabled bool) StdLoggerOption {
+	return func(o *stdLoggerOptions) {
+		o.parseLevels = enabled
+	}
+}
+
+// stdLogCallerSkip is the number of frames between stdWriter.Write and the user's code: Write itself,
+// log.(*Logger).output and the method of log.Logger. It is the depth log.Lshortfile uses for Print, Printf and the others,
+// a custom depth of log.Logger.Output is not known to the writer.
+const stdLogCallerSkip = 3
+
+type stdWriter struct {
+	l     Logger
+	level Level
+	o     *stdLoggerOptions
+}
+
+// NewStdLogger returns *log.Logger which writes every line of the output through l with the source=stdlog tag,
+// e.g. for http.Server.ErrorLog. The "[FATAL]" prefix is logged as an error, the process is never stopped by a line.
+func NewStdLogger(l Logger, level Level, opts ...StdLoggerOption) *log.Logger {
+	o := newStdLoggerOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return log.New(&stdWriter{l: l, level: level, o: o}, "", 0)
+}
+
+func (w *stdWriter) Write(p []byte) (int, error) {
+	ctx := w.l.WithTag(w.o.ctx, StdLogSourceTag, StdLogSourceValue)
+	for _, line := range strings.Split(string(p), "\n") {
+		msg := strings.TrimSpace(line)
+		if msg == "" {
+			continue
+		}
+
+		level := w.level
+		if w.o.parseLevels {
+			level, msg = parseLevelPrefix(msg, level)
+		}
+		if level == FatalLevel {
+			level = ErrorLevel
+		}
+		if l, ok := w.l.(*logger); ok {
+			l.logDepth(ctx, level, msg, stdLogCallerSkip)
+		} else {
+			w.l.Log(ctx, level, msg)
+		}
+	}
+	return len(p), nil
+}
+
+// parseLevelPrefix cuts the level in brackets from the beginning of msg, def is returned if there is no known level.
+func parseLevelPrefix(msg string, def Level) (Level, string) {
+	if !strings.HasPrefix(msg, "[") {
+		return def, msg
+	}
+	prefix, rest, ok := strings.Cut(msg[1:], "]")
+	if !ok {
+		return def, msg
+	}
+	level, err := ParseLevel(prefix)
+	if err != nil {
+		return def, msg
+	}
+	return level, strings.TrimSpace(rest)
+}
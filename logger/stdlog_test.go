@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+type stdRecordDriver struct {
+	recordDriver
+	levels  []Level
+	tags    []map[string]string
+	callers []*Caller
+}
+
+func (d *stdRecordDriver) Info(ctx context.Context, h EventHandler)    { d.add(h) }
+func (d *stdRecordDriver) Warning(ctx context.Context, h EventHandler) { d.add(h) }
+func (d *stdRecordDriver) Error(ctx context.Context, h EventHandler)   { d.add(h) }
+
+func (d *stdRecordDriver) add(h EventHandler) {
+	d.write(h)
+	d.levels = append(d.levels, h.Level())
+	tags := map[string]string{}
+	for k, v := range h.Tags() {
+		tags[k] = v
+	}
+	d.tags = append(d.tags, tags)
+	d.callers = append(d.callers, h.Caller())
+}
+
+func TestStdLogger(t *testing.T) {
+	d := &stdRecordDriver{}
+	l := New(d)
+
+	std := NewStdLogger(l, InfoLevel)
+	std.Print("first line\nsecond line")
+	std.Printf("[WARN] tls: handshake error")
+	std.Print("[FATAL] not fatal")
+	std.Print("[unknown] line")
+
+	msgs := []string{"first line", "second line", "tls: handshake error", "not fatal", "[unknown] line"}
+	levels := []Level{InfoLevel, InfoLevel, WarningLevel, ErrorLevel, InfoLevel}
+	if len(d.msgs) != len(msgs) {
+		t.Fatalf("Unexpected messages: %q", d.msgs)
+	}
+	for i := range msgs {
+		if d.msgs[i] != msgs[i] || d.levels[i] != levels[i] {
+			t.Errorf("Expected %v %q, got %v %q", levels[i], msgs[i], d.levels[i], d.msgs[i])
+		}
+		if d.tags[i][StdLogSourceTag] != StdLogSourceValue {
+			t.Errorf("Expected source tag, got %v", d.tags[i])
+		}
+	}
+}
+
+func TestStdLoggerCaller(t *testing.T) {
+	d := &stdRecordDriver{}
+	l := New(d, WithCaller(true))
+	std := NewStdLogger(l, InfoLevel)
+
+	_, _, line, _ := runtime.Caller(0)
+	std.Print("print")
+	std.Printf("printf %d", 1)
+	std.Println("println")
+	l.Log(context.Background(), InfoLevel, "log")
+
+	if len(d.callers) != 4 {
+		t.Fatalf("Expected 4 events, got %d", len(d.callers))
+	}
+	for i, c := range d.callers {
+		if c == nil || !strings.HasSuffix(c.File, "stdlog_test.go") || c.Line != line+1+i {
+			t.Errorf("Expected caller stdlog_test.go:%d, got %+v", line+1+i, c)
+		}
+	}
+}
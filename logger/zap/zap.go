@@ -3,10 +3,15 @@ package zap
 import (
 	"context"
 	"fmt"
+	"iter"
 	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"moul.io/http2curl"
 
 	"github.com/Pacman29/observability/internal/pool"
@@ -14,8 +19,8 @@ import (
 )
 
 type driver struct {
-	l       *zap.SugaredLogger
-	pool    *pool.Slice[any]
+	l       *zap.Logger
+	pool    *pool.Slice[zap.Field]
 	options *options
 }
 
@@ -27,52 +32,67 @@ func NewZapDriver(l *zap.SugaredLogger, opts ...Option) logger.Driver {
 
 	return &driver{
 		options: o,
-		l:       l,
-		pool:    pool.NewSlice[any](o.saveCap, o.createCap, nil),
+		l:       l.Desugar(),
+		pool:    pool.NewSlice[zap.Field](o.saveCap, o.createCap, nil),
 	}
 }
 
-func (d *driver) writeLog(ctx context.Context, writter func(msg string, kvs ...interface{}), h logger.EventHandler) {
-	args := d.pool.Get()
+func (d *driver) writeLog(ctx context.Context, level zapcore.Level, msg string, h logger.EventHandler, extra ...zap.Field) {
+	ce := d.l.Check(level, msg)
+	if ce == nil {
+		return
+	}
+	ce.Time = h.Time()
+
+	// zap would point to this driver, so the location of the logger call is used instead
+	if c := h.Caller(); c != nil {
+		ce.Caller = zapcore.NewEntryCaller(c.PC, c.File, c.Line, true)
+		ce.Caller.Function = c.Function
+		if len(c.Stack) != 0 {
+			ce.Stack = formatStack(c.Stack)
+		}
+	}
+
+	fields := d.pool.Get()
 	defer func() {
-		d.pool.Save(args)
+		d.pool.Save(fields)
 	}()
-	args = d.toZapArgs(ctx, args, h)
-	writter(h.Msg(), args...)
+	fields = d.toZapFields(ctx, fields, h)
+	if frames := logger.ErrorStack(h.Err()); len(frames) != 0 {
+		// the stack of the error origin is more useful than the one of the logger call
+		ce.Stack = ""
+		fields = append(fields, zap.Any("stacktrace", frames))
+	}
+	fields = append(fields, extra...)
+	ce.Write(fields...)
 }
 
 func (d *driver) Trace(ctx context.Context, h logger.EventHandler) {
-	d.writeLog(ctx, d.l.Debugw, h)
+	d.writeLog(ctx, zapcore.DebugLevel, h.Msg(), h)
 }
 
 func (d *driver) Debug(ctx context.Context, h logger.EventHandler) {
-	d.writeLog(ctx, d.l.Debugw, h)
+	d.writeLog(ctx, zapcore.DebugLevel, h.Msg(), h)
 }
 
 func (d *driver) Warning(ctx context.Context, h logger.EventHandler) {
-	d.writeLog(ctx, d.l.Warnw, h)
+	d.writeLog(ctx, zapcore.WarnLevel, h.Msg(), h)
 }
 
 func (d *driver) Info(ctx context.Context, h logger.EventHandler) {
-	d.writeLog(ctx, d.l.Infow, h)
+	d.writeLog(ctx, zapcore.InfoLevel, h.Msg(), h)
 }
 
 func (d *driver) Error(ctx context.Context, h logger.EventHandler) {
-	d.writeLog(ctx, d.l.Errorw, h)
+	d.writeLog(ctx, zapcore.ErrorLevel, h.Msg(), h)
 }
 
 func (d *driver) Fatal(ctx context.Context, h logger.EventHandler) {
-	d.writeLog(ctx, d.l.Fatalw, h)
+	d.writeLog(ctx, zapcore.FatalLevel, h.Msg(), h)
 }
 
 func (d *driver) Recover(err any, ctx context.Context, h logger.EventHandler) {
-	args := d.pool.Get()
-	defer func() {
-		d.pool.Save(args)
-	}()
-	args = d.toZapArgs(ctx, args, h)
-	args = append(args, zap.Any("stack", debug.Stack()))
-	d.l.Errorw(fmt.Sprintf("Panic: %v", err), args...)
+	d.writeLog(ctx, zapcore.ErrorLevel, fmt.Sprintf("Panic: %v", err), h, zap.Any("stack", debug.Stack()))
 	_ = d.l.Sync()
 }
 
@@ -80,29 +100,114 @@ func (d *driver) Flush(timeout time.Duration) error {
 	return d.l.Sync()
 }
 
-func (d *driver) toZapArgs(ctx context.Context, args []any, h logger.EventHandler) []any {
+func (d *driver) toZapFields(ctx context.Context, fields []zap.Field, h logger.EventHandler) []zap.Field {
 	for k, v := range h.Tags() {
-		args = append(args, zap.String(k, v))
+		fields = append(fields, zap.String(k, v))
 	}
-	for k, v := range h.Fields() {
-		args = append(args, zap.Any(k, v))
+	for a := range h.Attrs() {
+		fields = append(fields, attrToZap(a))
 	}
 	if err := h.Err(); err != nil {
-		args = append(args, zap.Error(err))
-	}
-	for _, v := range h.Args() {
-		args = append(args, v)
+		fields = append(fields, zap.Error(err))
 	}
+	fields = sweeten(fields, h.Args())
 	if req := h.Req(); req != nil {
 		if reqString, err := http2curl.GetCurlCommand(req); err != nil {
-			d.l.Warn("can't convert request to curl", err)
+			d.l.Warn("can't convert request to curl", zap.Error(err))
 		} else {
-			args = append(args, zap.String("request", reqString.String()))
+			fields = append(fields, zap.String("request", reqString.String()))
 		}
 	}
 	if d.options.ctxArgsResolver != nil {
-		args = append(args, d.options.ctxArgsResolver(ctx)...)
+		fields = sweeten(fields, slices.All(d.options.ctxArgsResolver(ctx)))
+	}
+
+	return fields
+}
+
+// sweeten converts loosely typed key-value pairs the same way zap.SugaredLogger does:
+// zap.Field is used as is, a string key is paired with the next value.
+func sweeten(fields []zap.Field, args iter.Seq2[int, any]) []zap.Field {
+	var (
+		key    string
+		hasKey bool
+	)
+	for _, arg := range args {
+		if hasKey {
+			fields = append(fields, zap.Any(key, logger.Resolve(arg)))
+			hasKey = false
+			continue
+		}
+		switch v := arg.(type) {
+		case zap.Field:
+			fields = append(fields, v)
+		case logger.Attr:
+			fields = append(fields, attrToZap(v))
+		case string:
+			key, hasKey = v, true
+		default:
+			fields = append(fields, zap.Any("ignored", logger.Resolve(v)))
+		}
+	}
+	if hasKey {
+		fields = append(fields, zap.Any("ignored", key))
+	}
+	return fields
+}
+
+// RedactFields makes the redactor of logger.WithRedactor redact zap.Field args by their keys,
+// e.g. logger.NewRedactor(logger.RedactKeys(logger.RedactMask, logger.DefaultRedactKeys...), zap.RedactFields()).
+func RedactFields() logger.RedactRule {
+	return logger.RedactArgType(func(f zap.Field) (string, any) {
+		switch f.Type {
+		case zapcore.StringType:
+			return f.Key, f.String
+		case zapcore.ReflectType, zapcore.StringerType:
+			if r, ok := f.Interface.(logger.Redactable); ok {
+				return f.Key, r
+			}
+		}
+		return f.Key, nil
+	}, func(k string, v any) zap.Field {
+		return zap.Any(k, v)
+	})
+}
+
+// attrToZap converts a without reflection, only the values of KindAny go through zap.Any.
+func attrToZap(a logger.Attr) zap.Field {
+	switch a.Kind() {
+	case logger.KindString:
+		return zap.String(a.Key, a.String())
+	case logger.KindInt64:
+		return zap.Int64(a.Key, a.Int64())
+	case logger.KindUint64:
+		return zap.Uint64(a.Key, a.Uint64())
+	case logger.KindFloat64:
+		return zap.Float64(a.Key, a.Float64())
+	case logger.KindBool:
+		return zap.Bool(a.Key, a.Bool())
+	case logger.KindDuration:
+		return zap.Duration(a.Key, a.Duration())
+	case logger.KindTime:
+		return zap.Time(a.Key, a.Time())
+	case logger.KindStringer:
+		return zap.Stringer(a.Key, a.Stringer())
+	default:
+		return zap.Any(a.Key, logger.Resolve(a.Any()))
 	}
+}
 
-	return args
+func formatStack(frames []logger.Frame) string {
+	var b strings.Builder
+	for i, f := range frames {
+		if i != 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(f.Function)
+		b.WriteString("\n\t")
+		b.WriteString(f.File)
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(f.Line))
+	}
+	return b.String()
 }
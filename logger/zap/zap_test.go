@@ -0,0 +1,108 @@
+package zap
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/Pacman29/observability/logger"
+	"github.com/Pacman29/observability/logger/drivertest"
+)
+
+var levels = map[zapcore.Level]logger.Level{
+	zapcore.DebugLevel: logger.DebugLevel,
+	zapcore.InfoLevel:  logger.InfoLevel,
+	zapcore.WarnLevel:  logger.WarningLevel,
+	zapcore.ErrorLevel: logger.ErrorLevel,
+	zapcore.FatalLevel: logger.FatalLevel,
+}
+
+// recordCore keeps the written entries with the fields encoded to a map.
+type recordCore struct {
+	mu      *sync.Mutex
+	entries *[]drivertest.Entry
+}
+
+func (c recordCore) Enabled(zapcore.Level) bool {
+	return true
+}
+
+func (c recordCore) With([]zapcore.Field) zapcore.Core {
+	return c
+}
+
+func (c recordCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce.AddCore(ent, c)
+}
+
+func (c recordCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	e := drivertest.Entry{
+		Level:  levels[ent.Level],
+		Msg:    ent.Message,
+		Fields: enc.Fields,
+	}
+	if v, ok := enc.Fields["error"]; ok {
+		e.Err = fmt.Sprint(v)
+	}
+	if v, ok := enc.Fields["request"]; ok {
+		e.Request = fmt.Sprint(v)
+	}
+
+	c.mu.Lock()
+	*c.entries = append(*c.entries, e)
+	c.mu.Unlock()
+	return nil
+}
+
+func (c recordCore) Sync() error {
+	return nil
+}
+
+type exitHook func(code int)
+
+func (h exitHook) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {
+	h(1)
+}
+
+func TestDriver(t *testing.T) {
+	drivertest.Run(t, func(t *testing.T, exit func(code int)) drivertest.Harness {
+		core := recordCore{mu: &sync.Mutex{}, entries: &[]drivertest.Entry{}}
+		l := zap.New(core, zap.WithFatalHook(exitHook(exit)))
+
+		return drivertest.Harness{
+			Driver: NewZapDriver(l.Sugar()),
+			Entries: func() []drivertest.Entry {
+				core.mu.Lock()
+				defer core.mu.Unlock()
+				return *core.entries
+			},
+		}
+	}, drivertest.WithLevelMapping(func(l logger.Level) logger.Level {
+		if l == logger.TraceLevel {
+			return logger.DebugLevel
+		}
+		return l
+	}))
+}
+
+func TestRedactFields(t *testing.T) {
+	core := recordCore{mu: &sync.Mutex{}, entries: &[]drivertest.Entry{}}
+	r := logger.NewRedactor(logger.RedactKeys(logger.RedactMask, "password", "token"), RedactFields())
+	l := logger.New(NewZapDriver(zap.New(core).Sugar()), logger.WithRedactor(r))
+
+	l.Info(context.Background(), "msg", zap.String("password", "qwerty"), zap.Int("token", 42), zap.String("user", "bob"))
+
+	fields := (*core.entries)[0].Fields
+	if fields["password"] != logger.MaskedValue || fields["token"] != logger.MaskedValue || fields["user"] != "bob" {
+		t.Errorf("Unexpected fields: %v", fields)
+	}
+}
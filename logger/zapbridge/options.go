@@ -0,0 +1,46 @@
+package zapbridge
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+type options struct {
+	level        zapcore.LevelEnabler
+	redactor     *logger.Redactor
+	flushTimeout time.Duration
+}
+
+type Option func(o *options)
+
+func newOptions() *options {
+	return &options{
+		level:        zapcore.DebugLevel,
+		redactor:     nil,
+		flushTimeout: 5 * time.Second,
+	}
+}
+
+// WithLevel sets the levels which are passed to the driver, all levels are passed by default.
+func WithLevel(l zapcore.LevelEnabler) Option {
+	return func(o *options) {
+		o.level = l
+	}
+}
+
+// WithRedactor redacts the events before they are passed to the driver.
+func WithRedactor(r *logger.Redactor) Option {
+	return func(o *options) {
+		o.redactor = r
+	}
+}
+
+// WithFlushTimeout sets the timeout of Driver.Flush called by Sync, 5 seconds by default.
+func WithFlushTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.flushTimeout = timeout
+	}
+}
@@ -0,0 +1,169 @@
+package zapbridge
+
+import (
+	"context"
+	"maps"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+const contextKey = "context"
+
+// Context passes ctx to the core, so the event gets the tags and fields of ctx.
+// Other cores skip this field.
+func Context(ctx context.Context) zap.Field {
+	return zap.Field{Key: contextKey, Type: zapcore.SkipType, Interface: ctx}
+}
+
+type core struct {
+	d       logger.Driver
+	options *options
+
+	// bound is the data added by With
+	bound *bound
+}
+
+type bound struct {
+	fields map[string]any
+	err    error
+	ctx    context.Context
+}
+
+// NewCore returns zapcore.Core which passes the entries to d, so the libraries which take *zap.Logger
+// go through the same pipeline as the Logger. Fields added by With are kept as the bound fields,
+// zap.Error becomes the error of the event and Context passes the context.
+func NewCore(d logger.Driver, opts ...Option) zapcore.Core {
+	o := newOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &core{
+		d:       d,
+		options: o,
+		bound:   &bound{fields: map[string]any{}},
+	}
+}
+
+func (c *core) Enabled(level zapcore.Level) bool {
+	return c.options.level.Enabled(level)
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	if len(fields) == 0 {
+		return c
+	}
+
+	return &core{
+		d:       c.d,
+		options: c.options,
+		bound:   c.bound.with(fields),
+	}
+}
+
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	b := c.bound.with(fields)
+	ctx := b.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	e := logger.NewEvent(ent.Message).
+		SetLevel(level(ent.Level)).
+		SetTime(ent.Time).
+		SetErr(logger.ContextError(ctx)).
+		SetReq(logger.ContextRequest(ctx))
+	if b.err != nil {
+		e.SetErr(b.err)
+	}
+	if ent.Caller.Defined {
+		e.SetCaller(&logger.Caller{Frame: logger.Frame{
+			PC:       ent.Caller.PC,
+			Function: ent.Caller.Function,
+			File:     ent.Caller.File,
+			Line:     ent.Caller.Line,
+		}})
+	}
+
+	eventFields := logger.ContextFields(ctx)
+	if eventFields == nil {
+		eventFields = make(map[string]any, len(b.fields)+2)
+	}
+	maps.Copy(eventFields, b.fields)
+	if ent.LoggerName != "" {
+		eventFields[logger.LoggerNameKey] = ent.LoggerName
+	}
+	if ent.Stack != "" {
+		eventFields["stacktrace"] = ent.Stack
+	}
+	tags := logger.ContextTags(ctx)
+
+	if c.options.redactor != nil {
+		c.options.redactor.RedactFields(eventFields)
+		c.options.redactor.RedactTags(tags)
+		e.SetReq(c.options.redactor.RedactRequest(e.Req()))
+	}
+	for k, v := range eventFields {
+		e.SetField(k, v)
+	}
+	for k, v := range tags {
+		e.SetTag(k, v)
+	}
+
+	logger.Dispatch(ctx, c.d, e.Level(), e)
+	return nil
+}
+
+func (c *core) Sync() error {
+	return c.d.Flush(c.options.flushTimeout)
+}
+
+// with returns a copy of b with the fields added, the fields are encoded by zap into plain values.
+func (b *bound) with(fields []zapcore.Field) *bound {
+	nb := &bound{
+		fields: maps.Clone(b.fields),
+		err:    b.err,
+		ctx:    b.ctx,
+	}
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		switch {
+		case f.Type == zapcore.SkipType && f.Key == contextKey:
+			if ctx, ok := f.Interface.(context.Context); ok {
+				nb.ctx = ctx
+			}
+		case f.Type == zapcore.ErrorType && f.Key == "error":
+			nb.err, _ = f.Interface.(error)
+		default:
+			f.AddTo(enc)
+		}
+	}
+	maps.Copy(nb.fields, enc.Fields)
+	return nb
+}
+
+// level maps zap levels to the logger ones, DPanic and Panic are logged as errors, zap panics after the write itself.
+func level(l zapcore.Level) logger.Level {
+	switch {
+	case l < zapcore.InfoLevel:
+		return logger.DebugLevel
+	case l == zapcore.InfoLevel:
+		return logger.InfoLevel
+	case l == zapcore.WarnLevel:
+		return logger.WarningLevel
+	case l < zapcore.FatalLevel:
+		return logger.ErrorLevel
+	default:
+		return logger.FatalLevel
+	}
+}
@@ -0,0 +1,94 @@
+package zapbridge
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Pacman29/observability/logger"
+)
+
+type recordDriver struct {
+	events  []*logger.Event
+	flushed bool
+}
+
+func (d *recordDriver) write(h logger.EventHandler) {
+	d.events = append(d.events, logger.Snapshot(h))
+}
+
+func (d *recordDriver) Trace(ctx context.Context, h logger.EventHandler)   { d.write(h) }
+func (d *recordDriver) Debug(ctx context.Context, h logger.EventHandler)   { d.write(h) }
+func (d *recordDriver) Warning(ctx context.Context, h logger.EventHandler) { d.write(h) }
+func (d *recordDriver) Info(ctx context.Context, h logger.EventHandler)    { d.write(h) }
+func (d *recordDriver) Error(ctx context.Context, h logger.EventHandler)   { d.write(h) }
+func (d *recordDriver) Fatal(ctx context.Context, h logger.EventHandler)   { d.write(h) }
+func (d *recordDriver) Flush(timeout time.Duration) error {
+	d.flushed = true
+	return nil
+}
+func (d *recordDriver) Recover(err any, ctx context.Context, h logger.EventHandler) {
+	d.write(h)
+}
+
+func TestCore(t *testing.T) {
+	d := &recordDriver{}
+	l := logger.New(d)
+	ctx := l.WithTag(context.Background(), "request_id", "42")
+
+	zl := zap.New(NewCore(d), zap.AddCaller()).Named("db").With(zap.String("component", "pool"), Context(ctx))
+	err := errors.New("timeout")
+	zl.Warn("slow query", zap.Int("rows", 10), zap.Error(err))
+
+	if len(d.events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(d.events))
+	}
+	e := d.events[0]
+	if e.Level() != logger.WarningLevel || e.Msg() != "slow query" || e.Err() != err {
+		t.Errorf("Unexpected event: %v %q %v", e.Level(), e.Msg(), e.Err())
+	}
+
+	fields := map[string]any{}
+	for k, v := range e.Fields() {
+		fields[k] = v
+	}
+	expected := map[string]any{
+		"component":          "pool",
+		"rows":               int64(10),
+		logger.LoggerNameKey: "db",
+	}
+	for k, v := range expected {
+		if fields[k] != v {
+			t.Errorf("Expected field %s=%v, got %v", k, v, fields[k])
+		}
+	}
+	for k, v := range e.Tags() {
+		if k != "request_id" || v != "42" {
+			t.Errorf("Unexpected tag %s=%s", k, v)
+		}
+	}
+	if c := e.Caller(); c == nil || !strings.HasSuffix(c.File, "zapbridge_test.go") {
+		t.Errorf("Expected caller in the test, got %v", c)
+	}
+
+	if err := zl.Sync(); err != nil || !d.flushed {
+		t.Errorf("Expected Sync to flush the driver, got %v", err)
+	}
+}
+
+func TestCoreLevel(t *testing.T) {
+	d := &recordDriver{}
+	zl := zap.New(NewCore(d, WithLevel(zap.InfoLevel)))
+
+	zl.Debug("skipped")
+	zl.Error("error")
+	zl.DPanic("dpanic")
+
+	if len(d.events) != 2 || d.events[0].Level() != logger.ErrorLevel || d.events[1].Level() != logger.ErrorLevel {
+		t.Errorf("Unexpected events: %v", d.events)
+	}
+}
@@ -2,57 +2,33 @@ package metrics
 
 import (
 	"context"
-	"maps"
-)
-
-type key int
 
-const (
-	tagKey key = iota
+	"github.com/Pacman29/observability/obsctx"
 )
 
-func getTags(ctx context.Context) map[string]string {
-	if m, ok := ctx.Value(tagKey).(map[string]string); ok && m != nil {
-		return m
-	}
-	return nil
-}
-
 func addTagToCtx(ctx context.Context, k string, v string) context.Context {
-	m := getTags(ctx)
-	if len(m) == 0 {
-		return context.WithValue(ctx, tagKey, map[string]string{
-			k: v,
-		})
-	}
-
-	nm := make(map[string]string, len(m)+1)
-	maps.Copy(nm, m)
-	nm[k] = v
-	return context.WithValue(ctx, tagKey, nm)
+	return obsctx.WithSourceTag(ctx, obsctx.Metrics, k, v)
 }
 
 func addTagsToCtx(ctx context.Context, tgs map[string]string) context.Context {
-	m := getTags(ctx)
-	if len(m) == 0 {
-		nm := make(map[string]string, len(tgs))
-		maps.Copy(nm, tgs)
-		return context.WithValue(ctx, tagKey, nm)
-	}
-
-	nm := make(map[string]string, len(m)+len(tgs))
-	maps.Copy(nm, m)
-	maps.Copy(nm, tgs)
-	return context.WithValue(ctx, tagKey, nm)
+	return obsctx.WithSourceTags(ctx, obsctx.Metrics, tgs)
 }
 
-func getCtxTags(ctx context.Context) map[string]string {
-	m := getTags(ctx)
-	if m == nil {
-		return nil
-	}
-	nm := make(map[string]string, len(m))
-	maps.Copy(nm, m)
+// rangeTags calls f for the tags of obsctx read by the metrics: the tags added by the metrics
+// and the allow-listed tags of the other sources (see WithSharedTags).
+func (o *options) rangeTags(ctx context.Context, f func(k string, v string)) {
+	obsctx.RangeSources(ctx, func(k string, v string, src obsctx.Source) bool {
+		if src&obsctx.Metrics != 0 {
+			f(k, v)
+		} else if _, ok := o.sharedTags[k]; ok {
+			f(k, v)
+		}
+		return true
+	})
+}
 
-	return nm
+// ContextTags returns a copy of the tags of ctx added by Metrics.WithTag, Logger.WithTag or obsctx.
+// The tags are not filtered by WithSharedTags.
+func ContextTags(ctx context.Context) map[string]string {
+	return obsctx.Tags(defaultCtx(ctx))
 }
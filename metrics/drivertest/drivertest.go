@@ -0,0 +1,154 @@
+// Package drivertest checks that a metrics.Driver follows the contract of the metrics, the checks are shared
+// by the drivers of this module and can be used for any other driver.
+package drivertest
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/Pacman29/observability/metrics"
+)
+
+const reusedValue = "drivertest_reused"
+
+// Harness is the driver under the test and the inspector of its output. The inspectors are called after Driver.Flush
+// and return zero for the series which were not written.
+type Harness struct {
+	Driver    metrics.Driver
+	Counter   func(key string, tags map[string]string) float64
+	Gauge     func(key string, tags map[string]string) float64
+	Histogram func(key string, tags map[string]string) (count uint64, sum float64)
+}
+
+// Factory creates a new driver for each check.
+type Factory func(t *testing.T) Harness
+
+// Run runs all checks against the drivers created by f.
+func Run(t *testing.T, f Factory) {
+	checks := []struct {
+		name  string
+		check func(t *testing.T, h Harness)
+	}{
+		{"Counter", checkCounter},
+		{"Gauge", checkGauge},
+		{"Histogram", checkHistogram},
+		{"Tags", checkTags},
+		{"Concurrent", checkConcurrent},
+		{"PooledTags", checkPooledTags},
+		{"Close", checkClose},
+	}
+	for _, c := range checks {
+		t.Run(c.name, func(t *testing.T) {
+			c.check(t, f(t))
+		})
+	}
+}
+
+var tags = map[string]string{"drivertest_tag": "value"}
+
+func checkCounter(t *testing.T, h Harness) {
+	ctx := context.Background()
+	h.Driver.Counter(ctx, newHandler("drivertest_counter", 2, tags))
+	h.Driver.Increment(ctx, newHandler("drivertest_counter", 3, tags))
+	h.Driver.Flush()
+
+	if v := h.Counter("drivertest_counter", tags); v != 5 {
+		t.Errorf("Expected counter 5, got %v", v)
+	}
+}
+
+func checkGauge(t *testing.T, h Harness) {
+	ctx := context.Background()
+	h.Driver.Gauge(ctx, newHandler("drivertest_gauge", 5, tags))
+	h.Driver.Gauge(ctx, newHandler("drivertest_gauge", -2, tags))
+	h.Driver.Flush()
+
+	if v := h.Gauge("drivertest_gauge", tags); v != 3 {
+		t.Errorf("Expected gauge 3, got %v", v)
+	}
+}
+
+func checkHistogram(t *testing.T, h Harness) {
+	ctx := context.Background()
+	h.Driver.Histogram(ctx, newHandler("drivertest_histogram", 1.5, tags))
+	h.Driver.Timing(ctx, newHandler("drivertest_histogram", 10, tags))
+	h.Driver.Duration(ctx, newHandler("drivertest_histogram", 20, tags))
+	h.Driver.Flush()
+
+	if count, sum := h.Histogram("drivertest_histogram", tags); count != 3 || sum != 31.5 {
+		t.Errorf("Expected 3 observations with sum 31.5, got %d with sum %v", count, sum)
+	}
+}
+
+func checkTags(t *testing.T, h Harness) {
+	ctx := context.Background()
+	first := map[string]string{"drivertest_tag": "first"}
+	second := map[string]string{"drivertest_tag": "second"}
+	h.Driver.Increment(ctx, newHandler("drivertest_tagged", 1, first))
+	h.Driver.Increment(ctx, newHandler("drivertest_tagged", 2, second))
+	h.Driver.Flush()
+
+	if v := h.Counter("drivertest_tagged", first); v != 1 {
+		t.Errorf("Expected counter 1 for %v, got %v", first, v)
+	}
+	if v := h.Counter("drivertest_tagged", second); v != 2 {
+		t.Errorf("Expected counter 2 for %v, got %v", second, v)
+	}
+}
+
+// checkConcurrent writes the first events of the metrics concurrently, the driver must not create a metric twice.
+func checkConcurrent(t *testing.T, h Harness) {
+	const (
+		goroutines = 8
+		events     = 25
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < events; j++ {
+				h.Driver.Increment(context.Background(), newHandler("drivertest_concurrent_counter", 1, tags))
+				h.Driver.Histogram(context.Background(), newHandler("drivertest_concurrent_histogram", 1, tags))
+			}
+		}()
+	}
+	wg.Wait()
+	h.Driver.Flush()
+
+	if v := h.Counter("drivertest_concurrent_counter", tags); v != goroutines*events {
+		t.Errorf("Expected counter %d, got %v", goroutines*events, v)
+	}
+	if count, _ := h.Histogram("drivertest_concurrent_histogram", tags); count != goroutines*events {
+		t.Errorf("Expected %d observations, got %d", goroutines*events, count)
+	}
+}
+
+// checkPooledTags checks that the driver doesn't keep the tags of the handler after the call,
+// the metrics return them to the pool and reuse for the next event.
+func checkPooledTags(t *testing.T, h Harness) {
+	handler := newHandler("drivertest_pooled", 1, tags)
+	h.Driver.Increment(context.Background(), handler)
+	handler.reuse()
+	h.Driver.Flush()
+
+	if v := h.Counter("drivertest_pooled", tags); v != 1 {
+		t.Errorf("Expected counter 1, got %v", v)
+	}
+	reused := map[string]string{"drivertest_tag": reusedValue}
+	if v := h.Counter("drivertest_pooled", reused); v != 0 {
+		t.Errorf("Expected no series for the reused tags, got %v", v)
+	}
+}
+
+func checkClose(t *testing.T, h Harness) {
+	h.Driver.Increment(context.Background(), newHandler("drivertest_close", 1, tags))
+	h.Driver.Flush()
+	h.Driver.Close()
+
+	if v := h.Counter("drivertest_close", tags); v != 1 {
+		t.Errorf("Expected counter 1 after Close, got %v", v)
+	}
+}
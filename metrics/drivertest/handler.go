@@ -0,0 +1,50 @@
+package drivertest
+
+import (
+	"iter"
+	"maps"
+)
+
+// handler owns its tags like the handler of the metrics, so the checks can reuse it after the driver call.
+type handler struct {
+	key     string
+	value   float64
+	tags    map[string]string
+	buckets []float64
+}
+
+func newHandler(key string, value float64, tags map[string]string) *handler {
+	return &handler{
+		key:   key,
+		value: value,
+		tags:  maps.Clone(tags),
+	}
+}
+
+// reuse overwrites the tags of the handler as the next event would do.
+func (h *handler) reuse() {
+	for k := range h.tags {
+		h.tags[k] = reusedValue
+	}
+	h.value = 0
+}
+
+func (h *handler) Tags() iter.Seq2[string, string] {
+	return maps.All(h.tags)
+}
+
+func (h *handler) GetBuckets() []float64 {
+	return h.buckets
+}
+
+func (h *handler) GetTags() map[string]string {
+	return maps.Clone(h.tags)
+}
+
+func (h *handler) GetValue() float64 {
+	return h.value
+}
+
+func (h *handler) GetKey() string {
+	return h.key
+}
@@ -66,10 +66,10 @@ func (m *metrics) withArgs(ctx context.Context, handler *metricEventHandler, opt
 		maps.Copy(handler.tags, reader(ctx))
 	}
 
-	// потом все из контекста
-	for k, v := range getTags(ctx) {
+	// потом все из контекста, из общих тегов только разрешенные
+	m.o.rangeTags(ctx, func(k string, v string) {
 		handler.tags[k] = v
-	}
+	})
 
 	// потом все из опций
 	for k, v := range o.tags {
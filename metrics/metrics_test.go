@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Pacman29/observability/obsctx"
+)
+
+type tagsDriver struct {
+	tags map[string]string
+}
+
+func (d *tagsDriver) Counter(ctx context.Context, h EventHandler)   { d.tags = h.GetTags() }
+func (d *tagsDriver) Increment(ctx context.Context, h EventHandler) {}
+func (d *tagsDriver) Gauge(ctx context.Context, h EventHandler)     {}
+func (d *tagsDriver) Histogram(ctx context.Context, h EventHandler) {}
+func (d *tagsDriver) Timing(ctx context.Context, h EventHandler)    {}
+func (d *tagsDriver) Duration(ctx context.Context, h EventHandler)  {}
+func (d *tagsDriver) Flush()                                        {}
+func (d *tagsDriver) Close()                                        {}
+
+func TestSharedTags(t *testing.T) {
+	ctx := obsctx.WithTag(context.Background(), "tenant", "acme")
+	// тег логгера
+	ctx = obsctx.WithSourceTag(ctx, obsctx.Logger, "user", "bob")
+
+	d := &tagsDriver{}
+	m := New(d)
+	m.Counter(m.WithTag(ctx, "region", "eu"), "requests", 1)
+	if len(d.tags) != 1 || d.tags["region"] != "eu" {
+		t.Errorf("Expected only own tags by default, got %v", d.tags)
+	}
+
+	d = &tagsDriver{}
+	m = New(d, WithSharedTags("tenant", "user"))
+	m.Counter(m.WithTag(ctx, "region", "eu"), "requests", 1)
+	if len(d.tags) != 3 || d.tags["tenant"] != "acme" || d.tags["user"] != "bob" || d.tags["region"] != "eu" {
+		t.Errorf("Unexpected tags: %v", d.tags)
+	}
+
+	// тег метрик остается их тегом после записи логгером
+	d = &tagsDriver{}
+	m = New(d)
+	ctx = obsctx.WithSourceTag(m.WithTag(context.Background(), "k", "v"), obsctx.Logger, "k", "v")
+	m.Counter(ctx, "requests", 1)
+	if len(d.tags) != 1 || d.tags["k"] != "v" {
+		t.Errorf("Expected the tag added by both facades, got %v", d.tags)
+	}
+}
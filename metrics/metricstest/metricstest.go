@@ -0,0 +1,191 @@
+// Package metricstest provides the recording driver for the tests of the code which uses the metrics,
+// so the written values can be checked without a hand-written mock of metrics.Metrics.
+//
+//	m, rec := metricstest.NewMetrics()
+//	svc := NewService(m)
+//	svc.Handle(ctx)
+//	if v := rec.CounterValue("requests_total", map[string]string{"status": "ok"}); v != 1 {
+//		t.Errorf("Expected 1 request, got %v", v)
+//	}
+package metricstest
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/Pacman29/observability/metrics"
+)
+
+// Kind is the driver method which recorded the event.
+type Kind int
+
+const (
+	CounterKind Kind = iota
+	IncrementKind
+	GaugeKind
+	HistogramKind
+	TimingKind
+	DurationKind
+)
+
+func (k Kind) String() string {
+	switch k {
+	case CounterKind:
+		return "counter"
+	case IncrementKind:
+		return "increment"
+	case GaugeKind:
+		return "gauge"
+	case HistogramKind:
+		return "histogram"
+	case TimingKind:
+		return "timing"
+	case DurationKind:
+		return "duration"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a copy of the recorded event.
+type Event struct {
+	Kind    Kind
+	Key     string
+	Value   float64
+	Tags    map[string]string
+	Buckets []float64
+}
+
+// Recorder is the metrics.Driver which keeps all events in memory. The tags are copied,
+// since the metrics reuse them after the driver returns.
+type Recorder struct {
+	mu     sync.Mutex
+	events []Event
+	closed bool
+}
+
+// New returns an empty recorder.
+func New() *Recorder {
+	return &Recorder{}
+}
+
+// NewMetrics returns the metrics which write to a new recorder.
+func NewMetrics(opts ...metrics.Option) (metrics.Metrics, *Recorder) {
+	r := New()
+	return metrics.New(r, opts...), r
+}
+
+func (r *Recorder) record(kind Kind, handler metrics.EventHandler) {
+	e := Event{
+		Kind:    kind,
+		Key:     handler.GetKey(),
+		Value:   handler.GetValue(),
+		Tags:    maps.Collect(handler.Tags()),
+		Buckets: slices.Clone(handler.GetBuckets()),
+	}
+
+	r.mu.Lock()
+	r.events = append(r.events, e)
+	r.mu.Unlock()
+}
+
+func (r *Recorder) Counter(ctx context.Context, handler metrics.EventHandler) {
+	r.record(CounterKind, handler)
+}
+
+func (r *Recorder) Increment(ctx context.Context, handler metrics.EventHandler) {
+	r.record(IncrementKind, handler)
+}
+
+func (r *Recorder) Gauge(ctx context.Context, handler metrics.EventHandler) {
+	r.record(GaugeKind, handler)
+}
+
+func (r *Recorder) Histogram(ctx context.Context, handler metrics.EventHandler) {
+	r.record(HistogramKind, handler)
+}
+
+func (r *Recorder) Timing(ctx context.Context, handler metrics.EventHandler) {
+	r.record(TimingKind, handler)
+}
+
+func (r *Recorder) Duration(ctx context.Context, handler metrics.EventHandler) {
+	r.record(DurationKind, handler)
+}
+
+func (r *Recorder) Flush() {}
+
+// Close marks the recorder as closed, the events are kept.
+func (r *Recorder) Close() {
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+}
+
+// Closed reports whether Close was called.
+func (r *Recorder) Closed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.closed
+}
+
+// Events returns the recorded events in the order of the calls.
+func (r *Recorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.events)
+}
+
+// Reset drops the recorded events.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.events = nil
+	r.mu.Unlock()
+}
+
+// matches reports whether the event has all tags, the other tags of the event are ignored.
+func (e Event) matches(key string, tags map[string]string) bool {
+	if e.Key != key {
+		return false
+	}
+	for k, v := range tags {
+		if tag, ok := e.Tags[k]; !ok || tag != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Recorder) sum(key string, tags map[string]string, kinds ...Kind) float64 {
+	var sum float64
+	for _, e := range r.Events() {
+		if slices.Contains(kinds, e.Kind) && e.matches(key, tags) {
+			sum += e.Value
+		}
+	}
+	return sum
+}
+
+// CounterValue returns the sum of Counter and Increment of key with all tags, nil tags match any event of key.
+// The other tags of the events are ignored, so the tags added from the context don't break the check.
+func (r *Recorder) CounterValue(key string, tags map[string]string) float64 {
+	return r.sum(key, tags, CounterKind, IncrementKind)
+}
+
+// GaugeValue returns the sum of Gauge of key with all tags, the tags are matched like in CounterValue.
+func (r *Recorder) GaugeValue(key string, tags map[string]string) float64 {
+	return r.sum(key, tags, GaugeKind)
+}
+
+// HistogramObservations returns the values of Histogram, Timing and Duration of key in the order of the calls.
+func (r *Recorder) HistogramObservations(key string) []float64 {
+	var values []float64
+	for _, e := range r.Events() {
+		if (e.Kind == HistogramKind || e.Kind == TimingKind || e.Kind == DurationKind) && e.Key == key {
+			values = append(values, e.Value)
+		}
+	}
+	return values
+}
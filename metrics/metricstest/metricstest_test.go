@@ -0,0 +1,75 @@
+package metricstest
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Pacman29/observability/metrics"
+	"github.com/Pacman29/observability/metrics/drivertest"
+)
+
+func TestRecorder(t *testing.T) {
+	m, rec := NewMetrics()
+	ctx := m.WithTag(context.Background(), "request_id", "1")
+
+	m.Increment(ctx, "requests_total", 1, metrics.WithTag("status", "ok"))
+	m.Counter(ctx, "requests_total", 2, metrics.WithTag("status", "ok"))
+	m.Increment(ctx, "requests_total", 1, metrics.WithTag("status", "error"))
+	m.Gauge(ctx, "in_flight", 1)
+	m.Histogram(ctx, "duration_ms", 1.5)
+	m.Timing(ctx, "duration_ms", 10)
+	m.Duration(ctx, "duration_ms", 20*time.Millisecond)
+
+	if v := rec.CounterValue("requests_total", map[string]string{"status": "ok"}); v != 3 {
+		t.Errorf("Expected counter 3, got %v", v)
+	}
+	if v := rec.CounterValue("requests_total", nil); v != 4 {
+		t.Errorf("Expected counter 4 for all tags, got %v", v)
+	}
+	if v := rec.GaugeValue("in_flight", nil); v != 1 {
+		t.Errorf("Expected gauge 1, got %v", v)
+	}
+	if v := rec.HistogramObservations("duration_ms"); !slices.Equal(v, []float64{1.5, 10, 20}) {
+		t.Errorf("Expected observations [1.5 10 20], got %v", v)
+	}
+
+	// the tags of the handler are returned to the pool, the recorded ones must stay
+	if tags := rec.Events()[0].Tags; tags["status"] != "ok" || tags["request_id"] != "1" {
+		t.Errorf("Expected the tags of the first event, got %v", tags)
+	}
+
+	rec.Reset()
+	if len(rec.Events()) != 0 {
+		t.Error("Expected no events after Reset")
+	}
+}
+
+func TestDriver(t *testing.T) {
+	drivertest.Run(t, func(t *testing.T) drivertest.Harness {
+		rec := New()
+		return drivertest.Harness{
+			Driver: rec,
+			Counter: func(key string, tags map[string]string) float64 {
+				return rec.CounterValue(key, tags)
+			},
+			Gauge: func(key string, tags map[string]string) float64 {
+				return rec.GaugeValue(key, tags)
+			},
+			Histogram: func(key string, tags map[string]string) (uint64, float64) {
+				var (
+					count uint64
+					sum   float64
+				)
+				for _, e := range rec.Events() {
+					if e.Kind >= HistogramKind && e.matches(key, tags) {
+						count++
+						sum += e.Value
+					}
+				}
+				return count, sum
+			},
+		}
+	})
+}
@@ -9,6 +9,7 @@ type options struct {
 	tagsMapPoolSaveCapacity        int
 	tagsMapPoolCreateCapacity      int
 	ctxReaders                     []CtxReader
+	sharedTags                     map[string]struct{}
 	bucketsSlicePoolSaveCapacity   int
 	bucketsSlicePoolCreateCapacity int
 	defaultBuckets                 []float64
@@ -39,6 +40,20 @@ func WithTagsMapPoolSaveCapacity(c int) Option {
 	}
 }
 
+// WithSharedTags sets the tags added by the logger or obsctx which are added to the metrics, the tags of
+// Metrics.WithTag are always added. None of them are added by default, since every tag becomes a label
+// and must have a bounded set of values.
+func WithSharedTags(keys ...string) Option {
+	return func(o *options) {
+		if o.sharedTags == nil {
+			o.sharedTags = map[string]struct{}{}
+		}
+		for _, k := range keys {
+			o.sharedTags[k] = struct{}{}
+		}
+	}
+}
+
 func WithCtxReader(reader CtxReader) Option {
 	return func(o *options) {
 		o.ctxReaders = append(o.ctxReaders, reader)
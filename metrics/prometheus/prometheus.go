@@ -36,117 +36,109 @@ func NewPrometheusDriver(registerer prometheus.Registerer, opts ...Option) metri
 }
 
 func (d *driver) Counter(ctx context.Context, handler metrics.EventHandler) {
-	counter, labelNames, ok := d.counters.Get(handler.GetKey())
-
 	labelValues := d.labelsPool.Get()
 	defer d.labelsPool.Save(labelValues)
 
-	if !ok {
-		for k, v := range handler.Tags() {
-			labelValues = append(labelValues, v)
+	counter, labelNames := d.counters.GetOrAdd(handler.GetKey(), func() (*prometheus.CounterVec, []string) {
+		var labelNames []string
+		for k := range handler.Tags() {
 			labelNames = append(labelNames, k)
 		}
 
-		counter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		counter := prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: d.o.namespace,
 			Subsystem: d.o.subsystem,
 			Name:      handler.GetKey(),
 		}, labelNames)
-		d.counters.Add(handler.GetKey(), counter, labelNames)
 		d.registerer.MustRegister(counter)
-	} else {
-		m := handler.GetTags()
-		for _, v := range labelNames {
-			labelValues = append(labelValues, m[v])
-		}
+		return counter, labelNames
+	})
+
+	m := handler.GetTags()
+	for _, v := range labelNames {
+		labelValues = append(labelValues, m[v])
 	}
 
 	counter.WithLabelValues(labelValues...).Add(handler.GetValue())
 }
 
 func (d *driver) Increment(ctx context.Context, handler metrics.EventHandler) {
-	counter, labelNames, ok := d.counters.Get(handler.GetKey())
-
 	labelValues := d.labelsPool.Get()
 	defer d.labelsPool.Save(labelValues)
 
-	if !ok {
-		for k, v := range handler.Tags() {
-			labelValues = append(labelValues, v)
+	counter, labelNames := d.counters.GetOrAdd(handler.GetKey(), func() (*prometheus.CounterVec, []string) {
+		var labelNames []string
+		for k := range handler.Tags() {
 			labelNames = append(labelNames, k)
 		}
 
-		counter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		counter := prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: d.o.namespace,
 			Subsystem: d.o.subsystem,
 			Name:      handler.GetKey(),
 		}, labelNames)
-		d.counters.Add(handler.GetKey(), counter, labelNames)
 		d.registerer.MustRegister(counter)
-	} else {
-		m := handler.GetTags()
-		for _, v := range labelNames {
-			labelValues = append(labelValues, m[v])
-		}
+		return counter, labelNames
+	})
+
+	m := handler.GetTags()
+	for _, v := range labelNames {
+		labelValues = append(labelValues, m[v])
 	}
 
 	counter.WithLabelValues(labelValues...).Add(handler.GetValue())
 }
 
 func (d *driver) Gauge(ctx context.Context, handler metrics.EventHandler) {
-	gauger, labelNames, ok := d.gauge.Get(handler.GetKey())
-
 	labelValues := d.labelsPool.Get()
 	defer d.labelsPool.Save(labelValues)
 
-	if !ok {
-		for k, v := range handler.Tags() {
-			labelValues = append(labelValues, v)
+	gauger, labelNames := d.gauge.GetOrAdd(handler.GetKey(), func() (*prometheus.GaugeVec, []string) {
+		var labelNames []string
+		for k := range handler.Tags() {
 			labelNames = append(labelNames, k)
 		}
 
-		gauger = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		gauger := prometheus.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: d.o.namespace,
 			Subsystem: d.o.subsystem,
 			Name:      handler.GetKey(),
 		}, labelNames)
-		d.gauge.Add(handler.GetKey(), gauger, labelNames)
 		d.registerer.MustRegister(gauger)
-	} else {
-		m := handler.GetTags()
-		for _, v := range labelNames {
-			labelValues = append(labelValues, m[v])
-		}
+		return gauger, labelNames
+	})
+
+	m := handler.GetTags()
+	for _, v := range labelNames {
+		labelValues = append(labelValues, m[v])
 	}
 
 	gauger.WithLabelValues(labelValues...).Add(handler.GetValue())
 }
 
 func (d *driver) Histogram(ctx context.Context, handler metrics.EventHandler) {
-	histogrammer, labelNames, ok := d.histogram.Get(handler.GetKey())
-
 	labelValues := d.labelsPool.Get()
 	defer d.labelsPool.Save(labelValues)
 
-	if !ok {
-		for k, v := range handler.Tags() {
-			labelValues = append(labelValues, v)
+	histogrammer, labelNames := d.histogram.GetOrAdd(handler.GetKey(), func() (*prometheus.HistogramVec, []string) {
+		var labelNames []string
+		for k := range handler.Tags() {
 			labelNames = append(labelNames, k)
 		}
 
-		histogrammer = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		histogrammer := prometheus.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: d.o.namespace,
 			Subsystem: d.o.subsystem,
 			Name:      handler.GetKey(),
 			Buckets:   handler.GetBuckets(),
 		}, labelNames)
-		d.histogram.Add(handler.GetKey(), histogrammer, labelNames)
 		d.registerer.MustRegister(histogrammer)
-	} else {
-		m := handler.GetTags()
-		for _, v := range labelNames {
-			labelValues = append(labelValues, m[v])
-		}
+		return histogrammer, labelNames
+	})
+
+	m := handler.GetTags()
+	for _, v := range labelNames {
+		labelValues = append(labelValues, m[v])
 	}
 
 	histogrammer.WithLabelValues(labelValues...).Observe(handler.GetValue())
@@ -0,0 +1,56 @@
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/Pacman29/observability/metrics/drivertest"
+)
+
+func findMetric(t *testing.T, registry *prometheus.Registry, key string, tags map[string]string) *dto.Metric {
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	for _, f := range families {
+		if f.GetName() != key {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			if len(m.GetLabel()) != len(tags) {
+				continue
+			}
+			matched := true
+			for _, l := range m.GetLabel() {
+				if v, ok := tags[l.GetName()]; !ok || v != l.GetValue() {
+					matched = false
+				}
+			}
+			if matched {
+				return m
+			}
+		}
+	}
+	return nil
+}
+
+func TestDriver(t *testing.T) {
+	drivertest.Run(t, func(t *testing.T) drivertest.Harness {
+		registry := prometheus.NewRegistry()
+		return drivertest.Harness{
+			Driver: NewPrometheusDriver(registry),
+			Counter: func(key string, tags map[string]string) float64 {
+				return findMetric(t, registry, key, tags).GetCounter().GetValue()
+			},
+			Gauge: func(key string, tags map[string]string) float64 {
+				return findMetric(t, registry, key, tags).GetGauge().GetValue()
+			},
+			Histogram: func(key string, tags map[string]string) (uint64, float64) {
+				h := findMetric(t, registry, key, tags).GetHistogram()
+				return h.GetSampleCount(), h.GetSampleSum()
+			},
+		}
+	})
+}
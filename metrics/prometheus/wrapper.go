@@ -19,15 +19,6 @@ func newWrapper[T any](size int) *metricWrapper[T] {
 	}
 }
 
-func (w *metricWrapper[T]) Add(key string, metric *T, labelNames []string) {
-	w.lock.Lock()
-	w.m[key] = pair[T]{
-		metric: metric,
-		labels: labelNames,
-	}
-	w.lock.Unlock()
-}
-
 func (w *metricWrapper[T]) Get(key string) (*T, []string, bool) {
 	w.lock.RLock()
 	p, ok := w.m[key]
@@ -37,3 +28,23 @@ func (w *metricWrapper[T]) Get(key string) (*T, []string, bool) {
 	}
 	return p.metric, p.labels, true
 }
+
+// GetOrAdd returns the metric of key, the metric is created by create if it doesn't exist yet.
+// create is called under the lock, so concurrent calls don't register the same metric twice.
+func (w *metricWrapper[T]) GetOrAdd(key string, create func() (*T, []string)) (*T, []string) {
+	if metric, labels, ok := w.Get(key); ok {
+		return metric, labels
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if p, ok := w.m[key]; ok {
+		return p.metric, p.labels
+	}
+	metric, labels := create()
+	w.m[key] = pair[T]{
+		metric: metric,
+		labels: labels,
+	}
+	return metric, labels
+}
@@ -0,0 +1,147 @@
+// Package obsctx keeps the tags of the context shared by the logger and metrics. Logger.WithTag and Metrics.WithTag
+// write here, so a tag added through one facade reaches the other one.
+//
+// Each tag remembers its source. A facade always reads its own tags, the tags of the other sources
+// are filtered by its allow-list (see WithSharedTags of the logger and metrics).
+package obsctx
+
+import (
+	"context"
+	"iter"
+
+	"github.com/Pacman29/observability/internal/ctxstore"
+)
+
+// Source is the set of the facades which have added the tag. Adding the tag again merges the sources,
+// the value is the last one added.
+type Source uint8
+
+const (
+	Logger Source = 1 << iota
+	Metrics
+
+	// Shared is the source of the tags added by WithTag and WithTags of this package, they are read by the facades
+	// only if allow-listed.
+	Shared Source = 0
+)
+
+type key int
+
+const (
+	tagKey key = iota
+)
+
+type tag struct {
+	value  string
+	source Source
+}
+
+func getNode(ctx context.Context) *ctxstore.Node[tag] {
+	if ctx == nil {
+		return nil
+	}
+	n, _ := ctx.Value(tagKey).(*ctxstore.Node[tag])
+	return n
+}
+
+// getTags returns the tags of ctx, the map is shared and must not be modified.
+func getTags(ctx context.Context) map[string]tag {
+	return getNode(ctx).All()
+}
+
+func WithTag(ctx context.Context, k string, v string) context.Context {
+	return WithSourceTag(ctx, Shared, k, v)
+}
+
+func WithTags(ctx context.Context, tags map[string]string) context.Context {
+	return WithSourceTags(ctx, Shared, tags)
+}
+
+// WithSourceTag adds the tag on behalf of the facades of src, e.g. Logger|Metrics for the tag owned by both of them.
+func WithSourceTag(ctx context.Context, src Source, k string, v string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	n := getNode(ctx)
+	return context.WithValue(ctx, tagKey, n.With(k, tag{value: v, source: src | source(n, k)}))
+}
+
+// WithSourceTags adds the tags on behalf of the facades of src, see WithSourceTag.
+func WithSourceTags(ctx context.Context, src Source, tags map[string]string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	n := getNode(ctx)
+	m := make(map[string]tag, len(tags))
+	for k, v := range tags {
+		m[k] = tag{value: v, source: src | source(n, k)}
+	}
+	return context.WithValue(ctx, tagKey, n.WithAll(m))
+}
+
+// source returns the sources of the tag k already added to n.
+func source(n *ctxstore.Node[tag], k string) Source {
+	t, _ := n.Get(k)
+	return t.source
+}
+
+// Copy adds the tags of src to dst with their sources, the values of src win.
+func Copy(dst context.Context, src context.Context) context.Context {
+	tags := getTags(src)
+	if tags == nil {
+		return dst
+	}
+	if dst == nil {
+		dst = context.Background()
+	}
+	n := getNode(dst)
+	m := make(map[string]tag, len(tags))
+	for k, t := range tags {
+		m[k] = tag{value: t.value, source: t.source | source(n, k)}
+	}
+	return context.WithValue(dst, tagKey, n.WithAll(m))
+}
+
+// Tag returns the value of the tag k.
+func Tag(ctx context.Context, k string) (string, bool) {
+	t, ok := getTags(ctx)[k]
+	return t.value, ok
+}
+
+// All iterates over the tags without copying them.
+func All(ctx context.Context) iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		Range(ctx, yield)
+	}
+}
+
+// Range calls f for the tags until it returns false. Unlike All it doesn't allocate, f doesn't escape.
+func Range(ctx context.Context, f func(k string, v string) bool) {
+	for k, t := range getTags(ctx) {
+		if !f(k, t.value) {
+			return
+		}
+	}
+}
+
+// RangeSources is Range which passes the source of each tag to f.
+func RangeSources(ctx context.Context, f func(k string, v string, src Source) bool) {
+	for k, t := range getTags(ctx) {
+		if !f(k, t.value, t.source) {
+			return
+		}
+	}
+}
+
+// Tags returns a copy of the tags.
+func Tags(ctx context.Context) map[string]string {
+	tags := getTags(ctx)
+	if tags == nil {
+		return nil
+	}
+	res := make(map[string]string, len(tags))
+	for k, t := range tags {
+		res[k] = t.value
+	}
+	return res
+}
@@ -0,0 +1,53 @@
+package obsctx
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTags(t *testing.T) {
+	parent := WithTag(context.Background(), "tenant", "acme")
+	child := WithTags(parent, map[string]string{"tenant": "other", "region": "eu"})
+
+	if v, ok := Tag(parent, "tenant"); !ok || v != "acme" {
+		t.Errorf("Expected parent to be unchanged, got %q", v)
+	}
+	if tags := Tags(child); len(tags) != 2 || tags["tenant"] != "other" || tags["region"] != "eu" {
+		t.Errorf("Unexpected tags: %v", tags)
+	}
+
+	tags := Tags(child)
+	tags["tenant"] = "modified"
+	if v, _ := Tag(child, "tenant"); v != "other" {
+		t.Error("Expected Tags to return a copy")
+	}
+	if Tags(context.Background()) != nil {
+		t.Error("Expected no tags")
+	}
+}
+
+func TestSources(t *testing.T) {
+	sources := func(ctx context.Context) map[string]Source {
+		res := map[string]Source{}
+		RangeSources(ctx, func(k string, v string, src Source) bool {
+			res[k] = src
+			return true
+		})
+		return res
+	}
+
+	ctx := WithSourceTag(context.Background(), Metrics, "k", "v")
+	ctx = WithSourceTag(ctx, Logger, "k", "v")
+	ctx = WithSourceTags(ctx, Shared, map[string]string{"k": "new", "shared": "v"})
+	if src := sources(ctx); src["k"] != Logger|Metrics || src["shared"] != Shared {
+		t.Errorf("Expected the sources to be merged, got %v", src)
+	}
+	if v, _ := Tag(ctx, "k"); v != "new" {
+		t.Errorf("Expected the last value, got %q", v)
+	}
+
+	dst := WithSourceTag(context.Background(), Logger, "shared", "dst")
+	if src := sources(Copy(dst, ctx)); src["k"] != Logger|Metrics || src["shared"] != Logger {
+		t.Errorf("Expected Copy to merge the sources, got %v", src)
+	}
+}
@@ -0,0 +1,182 @@
+package propagation
+
+import (
+	"context"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Pacman29/observability/obsctx"
+)
+
+const (
+	// BaggageKey is the header of the W3C baggage, https://www.w3.org/TR/baggage/
+	BaggageKey = "baggage"
+
+	DefaultMaxBytes   = 8192
+	DefaultMaxMembers = 180
+)
+
+// Propagator passes the allow-listed tags of obsctx to other processes in the W3C baggage format.
+type Propagator struct {
+	keys map[string]struct{}
+	o    *options
+}
+
+func New(opts ...Option) *Propagator {
+	o := newOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	keys := make(map[string]struct{}, len(o.keys))
+	for _, k := range o.keys {
+		if isToken(k) {
+			keys[k] = struct{}{}
+		}
+	}
+	return &Propagator{
+		keys: keys,
+		o:    o,
+	}
+}
+
+// Inject writes the allow-listed tags of ctx added by the logger, metrics or obsctx into the baggage of c.
+// Members of the baggage which are not allow-listed are kept, the members which exceed the limits are dropped
+// one by one, so the too long upstream baggage loses only its tail.
+func (p *Propagator) Inject(ctx context.Context, c Carrier) {
+	tags := obsctx.Tags(ctx)
+
+	members := parseBaggage(c.Get(BaggageKey))
+	members = slices.DeleteFunc(members, func(m member) bool {
+		_, ok := tags[m.key]
+		return ok && p.allowed(m.key)
+	})
+	for _, k := range slices.Sorted(maps.Keys(tags)) {
+		if p.allowed(k) {
+			members = append(members, member{key: k, value: tags[k]})
+		}
+	}
+
+	if baggage := p.format(members); baggage != "" {
+		c.Set(BaggageKey, baggage)
+	}
+}
+
+// Extract restores the allow-listed members of the baggage of c as the logger tags of obsctx. The metrics read them
+// only if allowed by metrics.WithSharedTags, the members come from the client and may have any value.
+// The baggage longer than the limit is ignored.
+func (p *Propagator) Extract(ctx context.Context, c Carrier) context.Context {
+	baggage := c.Get(BaggageKey)
+	if len(baggage) > p.o.maxBytes {
+		return ctx
+	}
+
+	tags := map[string]string{}
+	for _, m := range parseBaggage(baggage) {
+		if p.allowed(m.key) && len(tags) < p.o.maxMembers {
+			tags[m.key] = m.value
+		}
+	}
+	if len(tags) == 0 {
+		return ctx
+	}
+
+	return obsctx.WithSourceTags(ctx, obsctx.Logger, tags)
+}
+
+func (p *Propagator) allowed(k string) bool {
+	_, ok := p.keys[k]
+	return ok
+}
+
+type member struct {
+	key   string
+	value string
+	// properties are kept only for the members which are passed through as is
+	properties string
+}
+
+func (p *Propagator) format(members []member) string {
+	var b strings.Builder
+	n := 0
+	for _, m := range members {
+		s := m.key + "=" + encodeValue(m.value) + m.properties
+		if n != 0 {
+			s = "," + s
+		}
+		if n == p.o.maxMembers || b.Len()+len(s) > p.o.maxBytes {
+			continue
+		}
+		b.WriteString(s)
+		n++
+	}
+	return b.String()
+}
+
+// parseBaggage returns the valid members of the baggage.
+func parseBaggage(s string) []member {
+	if s == "" {
+		return nil
+	}
+
+	var members []member
+	for _, item := range strings.Split(s, ",") {
+		pair, properties, _ := strings.Cut(item, ";")
+		k, v, ok := strings.Cut(pair, "=")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if !ok || !isToken(k) {
+			continue
+		}
+		value, err := url.PathUnescape(v)
+		if err != nil {
+			continue
+		}
+		if properties != "" {
+			properties = ";" + strings.TrimSpace(properties)
+		}
+		members = append(members, member{key: k, value: value, properties: properties})
+	}
+	return members
+}
+
+// encodeValue percent-encodes the bytes which are not allowed in the baggage value and the percent sign.
+func encodeValue(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isBaggageOctet(c) && c != '%' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0xf])
+	}
+	return b.String()
+}
+
+func isBaggageOctet(c byte) bool {
+	return c == 0x21 ||
+		(c >= 0x23 && c <= 0x2b) ||
+		(c >= 0x2d && c <= 0x3a) ||
+		(c >= 0x3c && c <= 0x5b) ||
+		(c >= 0x5d && c <= 0x7e)
+}
+
+// isToken checks the key against the token of RFC 7230.
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= 0x20 || c >= 0x7f || strings.IndexByte(`"(),/:;<=>?@[\]{}`, c) >= 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+package propagation
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pacman29/observability/logger"
+	"github.com/Pacman29/observability/metrics"
+)
+
+type nopLogDriver struct{}
+
+func (nopLogDriver) Trace(ctx context.Context, h logger.EventHandler)            {}
+func (nopLogDriver) Debug(ctx context.Context, h logger.EventHandler)            {}
+func (nopLogDriver) Warning(ctx context.Context, h logger.EventHandler)          {}
+func (nopLogDriver) Info(ctx context.Context, h logger.EventHandler)             {}
+func (nopLogDriver) Error(ctx context.Context, h logger.EventHandler)            {}
+func (nopLogDriver) Fatal(ctx context.Context, h logger.EventHandler)            {}
+func (nopLogDriver) Flush(timeout time.Duration) error                           { return nil }
+func (nopLogDriver) Recover(err any, ctx context.Context, h logger.EventHandler) {}
+
+type nopMetricsDriver struct{}
+
+func (nopMetricsDriver) Counter(ctx context.Context, h metrics.EventHandler)   {}
+func (nopMetricsDriver) Increment(ctx context.Context, h metrics.EventHandler) {}
+func (nopMetricsDriver) Gauge(ctx context.Context, h metrics.EventHandler)     {}
+func (nopMetricsDriver) Histogram(ctx context.Context, h metrics.EventHandler) {}
+func (nopMetricsDriver) Timing(ctx context.Context, h metrics.EventHandler)    {}
+func (nopMetricsDriver) Duration(ctx context.Context, h metrics.EventHandler)  {}
+func (nopMetricsDriver) Flush()                                                {}
+func (nopMetricsDriver) Close()                                                {}
+
+type tagsDriver struct {
+	nopMetricsDriver
+	tags map[string]string
+}
+
+func (d *tagsDriver) Counter(ctx context.Context, h metrics.EventHandler) { d.tags = h.GetTags() }
+
+func TestInjectExtract(t *testing.T) {
+	l, m := logger.New(nopLogDriver{}), metrics.New(nopMetricsDriver{})
+	p := New(WithKeys("tenant", "request_id", "flag"))
+
+	ctx := m.WithTags(context.Background(), map[string]string{"flag": "100%", "tenant": "ignored"})
+	ctx = l.WithTags(ctx, map[string]string{"tenant": "acme, inc;", "user": "bob"})
+
+	header := http.Header{}
+	header.Set(BaggageKey, "other=1;prop=2,tenant=old")
+	p.Inject(ctx, HeaderCarrier(header))
+
+	if got, expected := header.Get(BaggageKey), "other=1;prop=2,flag=100%25,tenant=acme%2C%20inc%3B"; got != expected {
+		t.Errorf("Expected baggage %q, got %q", expected, got)
+	}
+
+	ctx = p.Extract(context.Background(), HeaderCarrier(header))
+	if tags := l.Tags(ctx); len(tags) != 2 || tags["tenant"] != "acme, inc;" || tags["flag"] != "100%" {
+		t.Errorf("Unexpected logger tags: %v", tags)
+	}
+	if tags := metrics.ContextTags(ctx); len(tags) != 2 || tags["tenant"] != "acme, inc;" {
+		t.Errorf("Unexpected metrics tags: %v", tags)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	l := logger.New(nopLogDriver{})
+	p := New(WithKeys("a", "b", "c"), WithMaxBytes(7), WithMaxMembers(2))
+
+	carrier := MapCarrier{}
+	p.Inject(l.WithTags(context.Background(), map[string]string{"a": "1", "b": "22", "c": "3"}), carrier)
+	if carrier[BaggageKey] != "a=1,c=3" {
+		t.Errorf("Unexpected baggage: %q", carrier[BaggageKey])
+	}
+
+	ctx := p.Extract(context.Background(), MapCarrier{BaggageKey: strings.Repeat("a=1,", 3)})
+	if tags := l.Tags(ctx); tags != nil {
+		t.Errorf("Expected too long baggage to be ignored, got %v", tags)
+	}
+}
+
+func TestInjectOversizedBaggage(t *testing.T) {
+	l := logger.New(nopLogDriver{})
+	p := New(WithKeys("tenant"), WithMaxBytes(24))
+
+	carrier := MapCarrier{BaggageKey: "a=1,b=2,tenant=old,long=" + strings.Repeat("x", 20) + ",c=3"}
+	p.Inject(l.WithTag(context.Background(), "tenant", "acme"), carrier)
+	if carrier[BaggageKey] != "a=1,b=2,c=3,tenant=acme" {
+		t.Errorf("Expected the foreign members to be dropped one by one, got %q", carrier[BaggageKey])
+	}
+}
+
+func TestExtractSources(t *testing.T) {
+	p := New(WithKeys("tenant"))
+	ctx := p.Extract(context.Background(), MapCarrier{BaggageKey: "tenant=acme"})
+
+	// теги из baggage логгер читает всегда, метрики только разрешенные
+	l := logger.New(nopLogDriver{}, logger.WithSharedTags())
+	if tags := l.Tags(ctx); tags["tenant"] != "acme" {
+		t.Errorf("Expected the logger to read the extracted tag, got %v", tags)
+	}
+
+	d := &tagsDriver{}
+	metrics.New(d).Counter(ctx, "requests", 1)
+	if len(d.tags) != 0 {
+		t.Errorf("Expected the metrics not to read the extracted tag, got %v", d.tags)
+	}
+	metrics.New(d, metrics.WithSharedTags("tenant")).Counter(ctx, "requests", 1)
+	if d.tags["tenant"] != "acme" {
+		t.Errorf("Expected the metrics to read the allowed tag, got %v", d.tags)
+	}
+}
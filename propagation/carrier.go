@@ -0,0 +1,35 @@
+package propagation
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Carrier holds the baggage between the processes.
+type Carrier interface {
+	Get(key string) string
+	Set(key string, value string)
+}
+
+// HeaderCarrier is the carrier of the HTTP requests.
+type HeaderCarrier http.Header
+
+// Get joins the values of several headers as allowed by the baggage format.
+func (c HeaderCarrier) Get(key string) string {
+	return strings.Join(http.Header(c).Values(key), ",")
+}
+
+func (c HeaderCarrier) Set(key string, value string) {
+	http.Header(c).Set(key, value)
+}
+
+// MapCarrier is the carrier of the message queues and others which pass string maps.
+type MapCarrier map[string]string
+
+func (c MapCarrier) Get(key string) string {
+	return c[key]
+}
+
+func (c MapCarrier) Set(key string, value string) {
+	c[key] = value
+}
@@ -0,0 +1,38 @@
+package propagation
+
+type options struct {
+	keys       []string
+	maxBytes   int
+	maxMembers int
+}
+
+type Option func(o *options)
+
+func newOptions() *options {
+	return &options{
+		keys:       nil,
+		maxBytes:   DefaultMaxBytes,
+		maxMembers: DefaultMaxMembers,
+	}
+}
+
+// WithKeys adds the tags which are propagated, tags out of the list are neither injected nor extracted.
+func WithKeys(keys ...string) Option {
+	return func(o *options) {
+		o.keys = append(o.keys, keys...)
+	}
+}
+
+// WithMaxBytes limits the length of the baggage, 8192 bytes by default.
+func WithMaxBytes(n int) Option {
+	return func(o *options) {
+		o.maxBytes = n
+	}
+}
+
+// WithMaxMembers limits the number of the baggage members, 180 by default.
+func WithMaxMembers(n int) Option {
+	return func(o *options) {
+		o.maxMembers = n
+	}
+}